@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"archuser.org/go-game/engine"
+	"archuser.org/go-game/tui"
+)
+
+func runBench(args []string) error {
+	fs := commandFlags("bench", "")
+	configPath := fs.String("config", defaultConfigPath, "path to game configuration")
+	botName := fs.String("bot", "greedy", "strategy that plays before rendering so the screen is busy")
+	duration := fs.Duration("for", time.Hour, "simulated play time before rendering")
+	width := fs.Int("width", 160, "screen width in columns")
+	height := fs.Int("height", 50, "screen height in rows")
+	fs.Parse(args)
+	bot, err := engine.LookupStrategy(*botName)
+	if err != nil {
+		return err
+	}
+	options := engine.RealTime()
+	options.Seed = defaultSimulationSeed
+	game, err := engine.NewFromFile(*configPath, options)
+	if err != nil {
+		return fmt.Errorf("build game: %w", err)
+	}
+	game.Autoplay(bot, *duration)
+	result, err := tui.BenchmarkRender(game, *width, *height)
+	if err != nil {
+		return fmt.Errorf("benchmark render: %w", err)
+	}
+	writeBench(os.Stdout, result, *width, *height)
+	return nil
+}
+
+func writeBench(out io.Writer, result testing.BenchmarkResult, width, height int) {
+	fmt.Fprintf(out, "render %dx%d\t%s\t%s\n", width, height, result.String(), result.MemString())
+}
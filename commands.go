@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"archuser.org/go-game/config"
+	"archuser.org/go-game/engine"
+	"archuser.org/go-game/save"
+)
+
+const (
+	defaultCommand        = "play"
+	defaultSimulationSeed = 1
+)
+
+type command struct {
+	name        string
+	description string
+	run         func(args []string) error
+}
+
+var commands = []command{
+	{"play", "play the game (the default when no command is given)", runPlay},
+	{"validate", "check game configs and report the first problem in each", runValidate},
+	{"simulate", "let bots play a config and write a Markdown balance report", runSimulate},
+	{"bot", "let a bot play a fresh game and print its statistics", runBot},
+	{"newconfig", "write a starter game config to edit", runNewConfig},
+	{"convert", "convert a legacy config file to the current format", runConvert},
+	{"stats", "print the statistics of a profile's save as JSON or CSV", runStats},
+	{"bench", "measure the time and allocations of drawing one frame", runBench},
+	{"version", "print version and build information", runVersion},
+	{"help", "list commands", nil},
+}
+
+func runCommand(args []string) error {
+	name := defaultCommand
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+	if name == "help" {
+		printCommands(os.Stdout)
+		return nil
+	}
+	for _, command := range commands {
+		if command.name == name {
+			return command.run(args)
+		}
+	}
+	printCommands(os.Stderr)
+	return fmt.Errorf("unknown command %q", name)
+}
+
+func printCommands(out io.Writer) {
+	fmt.Fprintln(out, "usage: go-game [command] [flags]")
+	fmt.Fprintln(out)
+	for _, command := range commands {
+		fmt.Fprintf(out, "  %-10s %s\n", command.name, command.description)
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Run go-game <command> -h to list the flags of a command.")
+}
+
+func commandFlags(name, operands string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), strings.TrimSpace("usage: go-game "+name+" [flags] "+operands))
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func runValidate(args []string) error {
+	fs := commandFlags("validate", "[config ...]")
+	fs.Parse(args)
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{defaultConfigPath}
+	}
+	failed := 0
+	for _, path := range paths {
+		game, err := engine.NewFromFile(path, engine.RealTime())
+		if err != nil {
+			failed++
+			fmt.Printf("%s: %v\n", path, err)
+			continue
+		}
+		fmt.Printf("%s: ok (%d industries, %d workers)\n", path, len(game.Industries), workerCount(game))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d configs failed validation", failed, len(paths))
+	}
+	return nil
+}
+
+func workerCount(g *engine.Engine) int {
+	count := 0
+	for _, industry := range g.Industries {
+		count += len(industry.Workers)
+	}
+	return count
+}
+
+func runSimulate(args []string) error {
+	fs := commandFlags("simulate", "")
+	configPath := fs.String("config", defaultConfigPath, "path to game configuration")
+	bots := fs.String("bot", strings.Join(engine.StrategyNames(), ","), "comma-separated strategies to compare")
+	duration := fs.Duration("for", time.Hour, "simulated play time per strategy")
+	seed := fs.Uint64("seed", defaultSimulationSeed, "seed for random events, shared by every strategy")
+	outPath := fs.String("out", "", "write the report here (default stdout)")
+	fs.Parse(args)
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	return engine.WriteSimulation(cfg, *bots, *duration, *seed, *outPath)
+}
+
+func runBot(args []string) error {
+	fs := commandFlags("bot", "")
+	configPath := fs.String("config", defaultConfigPath, "path to game configuration")
+	botName := fs.String("bot", "greedy", "strategy to play ("+strings.Join(engine.StrategyNames(), ", ")+")")
+	duration := fs.Duration("for", time.Hour, "simulated play time")
+	format := fs.String("format", "json", "output format (json or csv)")
+	seed := fs.Uint64("seed", defaultSimulationSeed, "seed for random events")
+	fs.Parse(args)
+	bot, err := engine.LookupStrategy(*botName)
+	if err != nil {
+		return err
+	}
+	options := engine.RealTime()
+	options.Seed = *seed
+	game, err := engine.NewFromFile(*configPath, options)
+	if err != nil {
+		return fmt.Errorf("build game: %w", err)
+	}
+	game.Autoplay(bot, *duration)
+	return engine.WriteStats(os.Stdout, game.ExportStats(game.Now()), *format)
+}
+
+func runNewConfig(args []string) error {
+	fs := commandFlags("newconfig", "[path]")
+	force := fs.Bool("force", false, "overwrite an existing file")
+	fs.Parse(args)
+	path := "game.yml"
+	if fs.NArg() > 0 {
+		path = fs.Arg(0)
+	}
+	if _, err := os.Stat(path); err == nil && !*force {
+		return fmt.Errorf("%s already exists (use -force to overwrite)", path)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("check config: %w", err)
+	}
+	if err := os.WriteFile(path, config.Starter, 0o644); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	fmt.Printf("wrote a starter config to %s (check it with go-game validate %s)\n", path, path)
+	return nil
+}
+
+func runConvert(args []string) error {
+	fs := commandFlags("convert", "legacy-config")
+	outPath := fs.String("out", "", "write the converted config here (default stdout)")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("convert needs exactly one legacy config")
+	}
+	return ConvertLegacyFile(fs.Arg(0), *outPath)
+}
+
+func runStats(args []string) error {
+	fs := commandFlags("stats", "")
+	profileName := fs.String("profile", "", "profile name (stored under profiles/)")
+	configPath := fs.String("config", defaultConfigPath, "path to game configuration (default: the profile's copy if it has one)")
+	format := fs.String("format", "json", "output format (json or csv)")
+	fs.Parse(args)
+	profile, err := save.FindProfile(*profileName)
+	if err != nil {
+		return fmt.Errorf("open profile: %w", err)
+	}
+	if _, err := os.Stat(profile.ConfigPath()); err == nil && !flagSet(fs, "config") {
+		*configPath = profile.ConfigPath()
+	}
+	game, err := engine.NewFromFile(*configPath, engine.RealTime())
+	if err != nil {
+		return fmt.Errorf("build game: %w", err)
+	}
+	if err := save.Resume(game, profile); err != nil {
+		return fmt.Errorf("load save: %w", err)
+	}
+	return engine.WriteStats(os.Stdout, game.ExportStats(time.Now()), *format)
+}
+
+func runVersion(args []string) error {
+	fs := commandFlags("version", "")
+	asJSON := fs.Bool("json", false, "print the build information as JSON")
+	fs.Parse(args)
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(engine.CurrentBuild())
+	}
+	fmt.Println(engine.CurrentBuild())
+	return nil
+}
@@ -1,8 +1,10 @@
-package main
+package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -11,7 +13,19 @@ import (
 type GameConfig struct {
 	StartingResources  map[string]int          `yaml:"startingResources"`
 	StartingProduction []PassiveProductionSpec `yaml:"startingProduction"`
+	Upkeep             []PassiveProductionSpec `yaml:"upkeep"`
 	Industries         []IndustryConfig        `yaml:"industry"`
+	ResourceValues     map[string]float64      `yaml:"resourceValues"`
+	ResourceIcons      map[string]IconConfig   `yaml:"resourceIcons"`
+	Scripts            []string                `yaml:"scripts"`
+	Plugins            []string                `yaml:"plugins"`
+	Calendar           CalendarConfig          `yaml:"calendar"`
+	Dir                string                  `yaml:"-"`
+}
+
+type IconConfig struct {
+	Glyph string `yaml:"glyph"`
+	ASCII string `yaml:"ascii"`
 }
 
 type IndustryConfig struct {
@@ -31,6 +45,13 @@ type WorkerConfig struct {
 	AutoTier    int            `yaml:"autoTier"`
 	Level       int            `yaml:"level"`
 	Cost        map[string]int `yaml:"cost"`
+	Icon        IconConfig     `yaml:"icon"`
+}
+
+type CalendarConfig struct {
+	DayLength     time.Duration `yaml:"dayLength"`
+	DaysPerSeason int           `yaml:"daysPerSeason"`
+	Seasons       []string      `yaml:"seasons"`
 }
 
 type PassiveProductionSpec struct {
@@ -44,7 +65,15 @@ func LoadConfig(path string) (GameConfig, error) {
 	if err != nil {
 		return GameConfig{}, fmt.Errorf("read config: %w", err)
 	}
+	cfg, err := ParseConfig(data)
+	if err != nil {
+		return GameConfig{}, err
+	}
+	cfg.Dir = filepath.Dir(path)
+	return cfg, nil
+}
 
+func ParseConfig(data []byte) (GameConfig, error) {
 	var cfg GameConfig
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return GameConfig{}, fmt.Errorf("parse yaml: %w", err)
@@ -93,17 +122,46 @@ func LoadConfig(path string) (GameConfig, error) {
 		cfg.Industries[i] = industry
 	}
 
-	for i, production := range cfg.StartingProduction {
+	for resource, value := range cfg.ResourceValues {
+		if value < 0 {
+			return GameConfig{}, fmt.Errorf("resource value %s must not be negative", resource)
+		}
+	}
+
+	if err := validatePassive("starting production", cfg.StartingProduction); err != nil {
+		return GameConfig{}, err
+	}
+	if err := validatePassive("upkeep", cfg.Upkeep); err != nil {
+		return GameConfig{}, err
+	}
+
+	return cfg, nil
+}
+
+func validatePassive(kind string, specs []PassiveProductionSpec) error {
+	for i, production := range specs {
 		if production.Resource == "" {
-			return GameConfig{}, fmt.Errorf("starting production %d missing resource", i)
+			return fmt.Errorf("%s %d missing resource", kind, i)
 		}
 		if production.ProdRate <= 0 {
-			return GameConfig{}, fmt.Errorf("starting production %s missing prodRate", production.Resource)
+			return fmt.Errorf("%s %s missing prodRate", kind, production.Resource)
 		}
 		if production.ProdQuant <= 0 {
-			return GameConfig{}, fmt.Errorf("starting production %s missing prodQuant", production.Resource)
+			return fmt.Errorf("%s %s missing prodQuant", kind, production.Resource)
 		}
 	}
+	return nil
+}
 
-	return cfg, nil
+func MarshalYAML(value any) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
 }
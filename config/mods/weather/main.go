@@ -0,0 +1,59 @@
+//go:build wasip1
+
+package main
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+//go:wasmimport go_game_v1 resource
+func resource(ptr unsafe.Pointer, size uint32) int64
+
+//go:wasmimport go_game_v1 add
+func add(ptr unsafe.Pointer, size uint32, amount int64)
+
+//go:wasmimport go_game_v1 register_resource
+func registerResource(ptr unsafe.Pointer, size uint32, start int64)
+
+//go:wasmimport go_game_v1 notify
+func notify(ptr unsafe.Pointer, size uint32)
+
+//go:wasmimport go_game_v1 panel_line
+func panelLine(ptr unsafe.Pointer, size uint32)
+
+const (
+	rain      = "rain"
+	stormMs   = 45_000
+	stormRain = 5
+)
+
+var sinceStorm int64
+
+func call(fn func(unsafe.Pointer, uint32), text string) {
+	fn(unsafe.Pointer(unsafe.StringData(text)), uint32(len(text)))
+}
+
+//go:wasmexport on_load
+func onLoad() {
+	registerResource(unsafe.Pointer(unsafe.StringData(rain)), uint32(len(rain)), 0)
+}
+
+//go:wasmexport on_tick
+func onTick(ms int64) {
+	sinceStorm += ms
+	if sinceStorm < stormMs {
+		return
+	}
+	sinceStorm = 0
+	add(unsafe.Pointer(unsafe.StringData(rain)), uint32(len(rain)), stormRain)
+	call(notify, fmt.Sprintf("A storm rolls in: +%d rain", stormRain))
+}
+
+//go:wasmexport panel
+func panel() {
+	call(panelLine, fmt.Sprintf("Rain collected: %d", resource(unsafe.Pointer(unsafe.StringData(rain)), uint32(len(rain)))))
+	call(panelLine, fmt.Sprintf("Next storm in %ds", (stormMs-sinceStorm)/1000))
+}
+
+func main() {}
@@ -0,0 +1,6 @@
+package config
+
+import _ "embed"
+
+//go:embed game.yml
+var Starter []byte
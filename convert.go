@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"archuser.org/go-game/config"
+)
+
+const (
+	legacyUpgradeMult = 1.5
+	legacyLevel       = 1
+)
+
+type legacyConfig struct {
+	StartingResources  map[string]int     `yaml:"startingResources"`
+	StartingProduction []legacyProduction `yaml:"startingProduction"`
+	Industries         []legacyIndustry   `yaml:"industry"`
+}
+
+type legacyIndustry struct {
+	Key      string         `yaml:"industry"`
+	Name     string         `yaml:"name"`
+	Resource string         `yaml:"resource"`
+	Workers  []legacyWorker `yaml:"workers"`
+}
+
+type legacyWorker struct {
+	Key         string         `yaml:"worker"`
+	WorkerName  string         `yaml:"workerName"`
+	Produces    string         `yaml:"produces"`
+	ProdRate    float64        `yaml:"prodRate"`
+	ProdQuant   int            `yaml:"prodQuant"`
+	UpgradeMult float64        `yaml:"upgradeMult"`
+	AutoTier    int            `yaml:"autoTier"`
+	Level       int            `yaml:"level"`
+	Cost        map[string]int `yaml:"cost"`
+}
+
+type legacyProduction struct {
+	Resource  string  `yaml:"resource"`
+	ProdRate  float64 `yaml:"prodRate"`
+	ProdQuant int     `yaml:"prodQuant"`
+}
+
+type configDocument struct {
+	StartingResources  map[string]int       `yaml:"startingResources"`
+	StartingProduction []productionDocument `yaml:"startingProduction,omitempty"`
+	Industries         []industryDocument   `yaml:"industry"`
+}
+
+type industryDocument struct {
+	Key      string           `yaml:"industry"`
+	Name     string           `yaml:"name"`
+	Resource string           `yaml:"resource"`
+	Workers  []workerDocument `yaml:"workers"`
+}
+
+type workerDocument struct {
+	Key         string         `yaml:"worker"`
+	WorkerName  string         `yaml:"workerName"`
+	Produces    string         `yaml:"produces"`
+	ProdRate    string         `yaml:"prodRate"`
+	ProdQuant   int            `yaml:"prodQuant"`
+	UpgradeMult float64        `yaml:"upgradeMult"`
+	AutoTier    int            `yaml:"autoTier"`
+	Level       int            `yaml:"level"`
+	Cost        map[string]int `yaml:"cost"`
+}
+
+type productionDocument struct {
+	Resource  string `yaml:"resource"`
+	ProdRate  string `yaml:"prodRate"`
+	ProdQuant int    `yaml:"prodQuant"`
+}
+
+func ConvertLegacyFile(inPath, outPath string) error {
+	data, err := os.ReadFile(inPath)
+	if err != nil {
+		return fmt.Errorf("read legacy config: %w", err)
+	}
+	payload, err := ConvertLegacyConfig(data)
+	if err != nil {
+		return err
+	}
+	if outPath == "" || outPath == "-" {
+		_, err = os.Stdout.Write(payload)
+		return err
+	}
+	if err := os.WriteFile(outPath, payload, 0o644); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return nil
+}
+
+func ConvertLegacyConfig(data []byte) ([]byte, error) {
+	var legacy legacyConfig
+	if err := yaml.Unmarshal(data, &legacy); err != nil {
+		return nil, fmt.Errorf("parse legacy yaml: %w", err)
+	}
+
+	doc := configDocument{StartingResources: legacy.StartingResources}
+	if doc.StartingResources == nil {
+		doc.StartingResources = map[string]int{}
+	}
+	for _, production := range legacy.StartingProduction {
+		doc.StartingProduction = append(doc.StartingProduction, productionDocument{
+			Resource:  production.Resource,
+			ProdRate:  legacySeconds(production.ProdRate),
+			ProdQuant: production.ProdQuant,
+		})
+	}
+	for _, industry := range legacy.Industries {
+		converted := industryDocument{
+			Key:      industry.Key,
+			Name:     industry.Name,
+			Resource: industry.Resource,
+		}
+		for _, worker := range industry.Workers {
+			converted.Workers = append(converted.Workers, convertLegacyWorker(worker))
+		}
+		doc.Industries = append(doc.Industries, converted)
+	}
+
+	payload, err := config.MarshalYAML(doc)
+	if err != nil {
+		return nil, fmt.Errorf("serialize config: %w", err)
+	}
+	if _, err := config.ParseConfig(payload); err != nil {
+		return nil, fmt.Errorf("converted config invalid: %w", err)
+	}
+	return payload, nil
+}
+
+func convertLegacyWorker(worker legacyWorker) workerDocument {
+	converted := workerDocument{
+		Key:         worker.Key,
+		WorkerName:  worker.WorkerName,
+		Produces:    worker.Produces,
+		ProdRate:    legacySeconds(worker.ProdRate),
+		ProdQuant:   worker.ProdQuant,
+		UpgradeMult: worker.UpgradeMult,
+		AutoTier:    worker.AutoTier,
+		Level:       worker.Level,
+		Cost:        make(map[string]int, len(worker.Cost)),
+	}
+	for resource, amount := range worker.Cost {
+		if resource == "coins" {
+			continue
+		}
+		converted.Cost[resource] = amount
+	}
+	if converted.UpgradeMult <= 0 {
+		converted.UpgradeMult = legacyUpgradeMult
+	}
+	if converted.Level <= 0 {
+		converted.Level = legacyLevel
+	}
+	if converted.WorkerName == "" {
+		converted.WorkerName = worker.Key
+	}
+	return converted
+}
+
+func legacySeconds(seconds float64) string {
+	if seconds <= 0 {
+		return "0s"
+	}
+	duration := time.Duration(math.Round(seconds * float64(time.Second)))
+	return duration.String()
+}
@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"time"
+)
+
+type Achievement struct {
+	Key         string
+	Name        string
+	Description string
+	Target      int
+	progress    func(g *Engine) int
+}
+
+var Achievements = []Achievement{
+	{Key: "first-hire", Name: "First Hire", Description: "buy a worker", Target: 1, progress: purchasesMade},
+	{Key: "crew", Name: "Crew", Description: "own 100 workers", Target: 100, progress: ownedWorkers},
+	{Key: "first-upgrade", Name: "Tinkerer", Description: "buy an upgrade", Target: 1, progress: upgradesBought},
+	{Key: "upgrader", Name: "Upgrader", Description: "buy 25 upgrades", Target: 25, progress: upgradesBought},
+	{Key: "hands-off", Name: "Hands Off", Description: "automate a worker", Target: 1, progress: autoWorkers},
+	{Key: "assembly-line", Name: "Assembly Line", Description: "automate 5 workers", Target: 5, progress: autoWorkers},
+	{Key: "busy", Name: "Busy", Description: "complete 100 cycles", Target: 100, progress: totalCycles},
+	{Key: "grind", Name: "Grind", Description: "complete 10,000 cycles", Target: 10000, progress: totalCycles},
+	{Key: "earner", Name: "Earner", Description: "earn 1,000 of one resource", Target: 1000, progress: bestEarned},
+	{Key: "tycoon", Name: "Tycoon", Description: "earn 1,000,000 of one resource", Target: 1000000, progress: bestEarned},
+	{Key: "spender", Name: "Big Spender", Description: "spend 10,000 resources", Target: 10000, progress: totalSpent},
+	{Key: "marathon", Name: "Marathon", Description: "play for one hour", Target: 3600, progress: playedSeconds},
+}
+
+func (g *Engine) AchievementProgress(achievement Achievement) int {
+	return MinInt(achievement.progress(g), achievement.Target)
+}
+
+func (g *Engine) checkAchievements(now time.Time) {
+	for _, achievement := range Achievements {
+		if _, ok := g.Stats.Achievements[achievement.Key]; ok {
+			continue
+		}
+		if achievement.progress(g) < achievement.Target {
+			continue
+		}
+		g.Stats.Achievements[achievement.Key] = now
+		g.Notify(NoticeAchievement, tr("achievement unlocked: %s", achievement.Name))
+	}
+}
+
+func ownedWorkers(g *Engine) int {
+	total := 0
+	for _, industry := range g.Industries {
+		for _, worker := range industry.Workers {
+			total += worker.Owned
+		}
+	}
+	return total
+}
+
+func purchasesMade(g *Engine) int {
+	total := 0
+	for _, purchase := range g.Stats.Purchases {
+		if purchase.Kind == purchaseBuy {
+			total += purchase.Count
+		}
+	}
+	return total
+}
+
+func upgradesBought(g *Engine) int {
+	total := 0
+	for _, industry := range g.Industries {
+		for _, worker := range industry.Workers {
+			total += worker.Tier - 1
+		}
+	}
+	return total
+}
+
+func autoWorkers(g *Engine) int {
+	total := 0
+	for _, industry := range g.Industries {
+		for _, worker := range industry.Workers {
+			if worker.Auto {
+				total++
+			}
+		}
+	}
+	return total
+}
+
+func totalCycles(g *Engine) int {
+	total := 0
+	for _, cycles := range g.Stats.Cycles {
+		total += cycles
+	}
+	return total
+}
+
+func bestEarned(g *Engine) int {
+	best := 0
+	for _, earned := range g.Stats.Earned {
+		best = MaxInt(best, earned)
+	}
+	return best
+}
+
+func totalSpent(g *Engine) int {
+	total := 0
+	for _, spent := range g.Stats.Spent {
+		total += spent
+	}
+	return total
+}
+
+func playedSeconds(g *Engine) int {
+	return int(g.Stats.Playtime / time.Second)
+}
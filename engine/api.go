@@ -0,0 +1,85 @@
+package engine
+
+type Snapshot struct {
+	Resources  map[string]int     `json:"resources"`
+	Rates      map[string]float64 `json:"rates"`
+	NetWorth   int                `json:"netWorth"`
+	Paused     bool               `json:"paused"`
+	Revision   int                `json:"revision"`
+	Industries []IndustrySnapshot `json:"industries"`
+}
+
+type IndustrySnapshot struct {
+	Key     string           `json:"key"`
+	Name    string           `json:"name"`
+	Workers []WorkerSnapshot `json:"workers"`
+}
+
+type WorkerSnapshot struct {
+	Key             string         `json:"key"`
+	Name            string         `json:"name"`
+	Owned           int            `json:"owned"`
+	Tier            int            `json:"tier"`
+	Auto            bool           `json:"auto"`
+	Running         bool           `json:"running"`
+	BuyCost         map[string]int `json:"buyCost"`
+	UpgradeCost     map[string]int `json:"upgradeCost"`
+	BuyPayback      float64        `json:"buyPaybackSeconds,omitempty"`
+	UpgradePayback  float64        `json:"upgradePaybackSeconds,omitempty"`
+	BuyAffordIn     float64        `json:"buyAffordSeconds"`
+	UpgradeAffordIn float64        `json:"upgradeAffordSeconds"`
+}
+
+type Target struct {
+	Industry string `json:"industry"`
+	Worker   string `json:"worker"`
+	Count    int    `json:"count"`
+}
+
+func (g *Engine) Snapshot() Snapshot {
+	state := Snapshot{
+		Resources: CopyResources(g.Resources),
+		Rates:     g.Rates(),
+		NetWorth:  g.NetWorth(),
+		Revision:  g.Revision(),
+	}
+	for industryIndex, industry := range g.Industries {
+		entry := IndustrySnapshot{Key: industry.Key, Name: industry.Name}
+		for workerIndex := range industry.Workers {
+			entry.Workers = append(entry.Workers, g.snapshotWorker(industryIndex, workerIndex))
+		}
+		state.Industries = append(state.Industries, entry)
+	}
+	return state
+}
+
+func (g *Engine) snapshotWorker(industryIndex, workerIndex int) WorkerSnapshot {
+	worker := g.Industries[industryIndex].Workers[workerIndex]
+	buy, _ := g.BuyPayback(industryIndex, workerIndex)
+	upgrade, _ := g.UpgradePayback(industryIndex, workerIndex)
+	return WorkerSnapshot{
+		Key:             worker.Definition.Key,
+		Name:            worker.Definition.WorkerName,
+		Owned:           worker.Owned,
+		Tier:            worker.Tier,
+		Auto:            worker.Auto,
+		Running:         worker.Running,
+		BuyCost:         worker.Definition.Cost,
+		UpgradeCost:     g.UpgradeCost(industryIndex, workerIndex),
+		BuyPayback:      buy.Seconds(),
+		UpgradePayback:  upgrade.Seconds(),
+		BuyAffordIn:     g.affordSeconds(worker.Definition.Cost),
+		UpgradeAffordIn: g.affordSeconds(g.UpgradeCost(industryIndex, workerIndex)),
+	}
+}
+
+func (g *Engine) FindTarget(industryKey, workerKey string) (int, int, bool) {
+	for industryIndex, industry := range g.Industries {
+		if industry.Key != industryKey {
+			continue
+		}
+		workerIndex, ok := FindWorkerIndex(industry.Workers, workerKey)
+		return industryIndex, workerIndex, ok
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,169 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	BotInterval  = time.Second
+	autoplayStep = 100 * time.Millisecond
+)
+
+type Strategy interface {
+	Decide(state Snapshot) []BotAction
+}
+
+type BotAction struct {
+	Kind   string
+	Target Target
+}
+
+type greedyBot struct{}
+
+type cheapestBot struct{}
+
+type plannerBot struct{}
+
+type botOption struct {
+	action   BotAction
+	cost     map[string]int
+	payback  float64
+	affordIn float64
+}
+
+var strategies = map[string]Strategy{
+	"greedy":   greedyBot{},
+	"cheapest": cheapestBot{},
+	"planner":  plannerBot{},
+}
+
+func LookupStrategy(name string) (Strategy, error) {
+	strategy, ok := strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown bot %q (%s)", name, strings.Join(StrategyNames(), ", "))
+	}
+	return strategy, nil
+}
+
+func StrategyNames() []string {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (greedyBot) Decide(state Snapshot) []BotAction {
+	return decideBest(state, func(cost map[string]int, payback float64) float64 { return payback })
+}
+
+func (cheapestBot) Decide(state Snapshot) []BotAction {
+	return decideBest(state, func(cost map[string]int, payback float64) float64 { return float64(costTotal(cost)) })
+}
+
+func (plannerBot) Decide(state Snapshot) []BotAction {
+	actions := manualRuns(state)
+	best, ok := bestOption(state, func(option botOption) (float64, bool) {
+		return option.affordIn + option.payback, option.affordIn >= 0
+	})
+	if ok && best.affordIn == 0 {
+		actions = append(actions, best.action)
+	}
+	return actions
+}
+
+func decideBest(state Snapshot, score func(cost map[string]int, payback float64) float64) []BotAction {
+	actions := manualRuns(state)
+	best, ok := bestOption(state, func(option botOption) (float64, bool) {
+		return score(option.cost, option.payback), CanAfford(option.cost, state.Resources)
+	})
+	if ok {
+		actions = append(actions, best.action)
+	}
+	return actions
+}
+
+func manualRuns(state Snapshot) []BotAction {
+	var actions []BotAction
+	for _, industry := range state.Industries {
+		for _, worker := range industry.Workers {
+			if worker.Owned > 0 && !worker.Auto && !worker.Running {
+				actions = append(actions, BotAction{Kind: ReplayRun, Target: Target{Industry: industry.Key, Worker: worker.Key}})
+			}
+		}
+	}
+	return actions
+}
+
+func bestOption(state Snapshot, score func(option botOption) (float64, bool)) (botOption, bool) {
+	var best botOption
+	bestScore, found := 0.0, false
+	for _, option := range botOptions(state) {
+		value, ok := score(option)
+		if option.payback <= 0 || !ok {
+			continue
+		}
+		if !found || value < bestScore {
+			best, bestScore, found = option, value, true
+		}
+	}
+	return best, found
+}
+
+func botOptions(state Snapshot) []botOption {
+	var options []botOption
+	for _, industry := range state.Industries {
+		for _, worker := range industry.Workers {
+			target := Target{Industry: industry.Key, Worker: worker.Key, Count: 1}
+			options = append(options,
+				botOption{action: BotAction{Kind: replayBuy, Target: target}, cost: worker.BuyCost, payback: worker.BuyPayback, affordIn: worker.BuyAffordIn},
+				botOption{action: BotAction{Kind: replayUpgrade, Target: target}, cost: worker.UpgradeCost, payback: worker.UpgradePayback, affordIn: worker.UpgradeAffordIn},
+			)
+		}
+	}
+	return options
+}
+
+func costTotal(cost map[string]int) int {
+	total := 0
+	for _, amount := range cost {
+		total += amount
+	}
+	return total
+}
+
+func (g *Engine) Perform(action BotAction) (Status, bool) {
+	industryIndex, workerIndex, ok := g.FindTarget(action.Target.Industry, action.Target.Worker)
+	if !ok {
+		return ErrorStatus(fmt.Sprintf("unknown worker %s/%s", action.Target.Industry, action.Target.Worker)), false
+	}
+	switch action.Kind {
+	case replayBuy:
+		return g.BuyCount(industryIndex, workerIndex, MaxInt(action.Target.Count, 1)), true
+	case replayUpgrade:
+		return g.UpgradeWorker(industryIndex, workerIndex), true
+	case ReplayRun:
+		return g.StartRun(industryIndex, workerIndex, g.Now()), true
+	}
+	return ErrorStatus(fmt.Sprintf("unknown action %q", action.Kind)), false
+}
+
+func (g *Engine) Autoplay(strategy Strategy, duration time.Duration) {
+	var decidedAt time.Duration
+	for elapsed := time.Duration(0); elapsed <= duration; elapsed += autoplayStep {
+		g.Step(min(autoplayStep, elapsed))
+		g.TakeCompletions()
+		g.TakeNotices()
+		if elapsed-decidedAt < BotInterval && elapsed > 0 {
+			continue
+		}
+		decidedAt = elapsed
+		for _, action := range strategy.Decide(g.Snapshot()) {
+			g.Perform(action)
+		}
+	}
+}
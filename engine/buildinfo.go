@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"cmp"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+)
+
+const (
+	devVersion  = "dev"
+	shortCommit = 7
+)
+
+var (
+	version   string
+	commit    string
+	buildDate string
+)
+
+type BuildInfo struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit,omitempty"`
+	Date    string `json:"date,omitempty"`
+	Dirty   bool   `json:"dirty,omitempty"`
+	Go      string `json:"go"`
+}
+
+var CurrentBuild = sync.OnceValue(readBuild)
+
+func readBuild() BuildInfo {
+	build := BuildInfo{Version: version, Commit: commit, Date: buildDate, Go: runtime.Version()}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return build.withDefaults("")
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			build.Commit = cmp.Or(build.Commit, setting.Value)
+		case "vcs.time":
+			build.Date = cmp.Or(build.Date, setting.Value)
+		case "vcs.modified":
+			build.Dirty = setting.Value == "true"
+		}
+	}
+	return build.withDefaults(info.Main.Version)
+}
+
+func (b BuildInfo) withDefaults(module string) BuildInfo {
+	if b.Version == "" && b.Commit == "" && module != "" && module != "(devel)" {
+		b.Version = module
+	}
+	b.Version = cmp.Or(b.Version, devVersion)
+	return b
+}
+
+func (b BuildInfo) Short() string {
+	if b.Version != devVersion || b.Commit == "" {
+		return b.Version
+	}
+	label := devVersion + "-" + b.Commit[:min(len(b.Commit), shortCommit)]
+	if b.Dirty {
+		label += "+dirty"
+	}
+	return label
+}
+
+func (b BuildInfo) String() string {
+	details := []string{}
+	if b.Commit != "" {
+		details = append(details, "commit "+b.Commit)
+	}
+	if b.Dirty {
+		details = append(details, "modified")
+	}
+	if b.Date != "" {
+		details = append(details, "built "+b.Date)
+	}
+	details = append(details, b.Go)
+	return fmt.Sprintf("go-game %s (%s)", b.Version, strings.Join(details, ", "))
+}
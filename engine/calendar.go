@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"time"
+
+	"archuser.org/go-game/config"
+)
+
+const (
+	noticeCalendar       = "calendar"
+	defaultDayLength     = time.Minute
+	defaultDaysPerSeason = 10
+)
+
+var defaultSeasons = []string{"Spring", "Summer", "Autumn", "Winter"}
+
+type Date struct {
+	Year   int
+	Season int
+	Day    int
+	Name   string
+}
+
+type DayStarted struct {
+	At     time.Time
+	Date   Date
+	Passed int
+}
+
+func (d Date) String() string {
+	return tr("Day %d of %s, Year %d", d.Day, d.Name, d.Year)
+}
+
+func newCalendar(cfg config.CalendarConfig) config.CalendarConfig {
+	if cfg.DayLength <= 0 {
+		cfg.DayLength = defaultDayLength
+	}
+	if cfg.DaysPerSeason <= 0 {
+		cfg.DaysPerSeason = defaultDaysPerSeason
+	}
+	if len(cfg.Seasons) == 0 {
+		cfg.Seasons = defaultSeasons
+	}
+	return cfg
+}
+
+func (g *Engine) Day() int {
+	return int(g.CalendarTime / g.calendar.DayLength)
+}
+
+func (g *Engine) Date() Date {
+	return g.dateOf(g.Day())
+}
+
+func (g *Engine) dateOf(day int) Date {
+	seasons := len(g.calendar.Seasons)
+	season := day / g.calendar.DaysPerSeason
+	return Date{
+		Year:   season/seasons + 1,
+		Season: season % seasons,
+		Day:    day%g.calendar.DaysPerSeason + 1,
+		Name:   g.calendar.Seasons[season%seasons],
+	}
+}
+
+func (g *Engine) advanceCalendar(elapsed time.Duration, now time.Time) {
+	before := g.Date()
+	passed := g.Day()
+	g.CalendarTime += elapsed
+	if passed = g.Day() - passed; passed == 0 {
+		return
+	}
+	date := g.Date()
+	if date.Season != before.Season || date.Year != before.Year {
+		g.Notify(noticeCalendar, tr("%s of year %d has begun", date.Name, date.Year))
+	}
+	g.Events.publish(DayStarted{At: now, Date: date, Passed: passed})
+}
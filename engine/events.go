@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"time"
+)
+
+type ResourceChanged struct {
+	At       time.Time
+	Resource string
+	Delta    int
+}
+
+type CycleCompleted struct {
+	At       time.Time
+	Industry int
+	Worker   int
+	Resource string
+	Amount   int
+}
+
+type WorkerPurchased struct {
+	At       time.Time
+	Industry int
+	Worker   int
+	Count    int
+	Cost     map[string]int
+}
+
+type TierUpgraded struct {
+	At       time.Time
+	Industry int
+	Worker   int
+	Tier     int
+	Cost     map[string]int
+}
+
+type Ticked struct {
+	At      time.Time
+	Elapsed time.Duration
+}
+
+type EventBus struct {
+	handlers []func(any)
+}
+
+func Subscribe[E any](bus *EventBus, handler func(E)) {
+	bus.handlers = append(bus.handlers, typedHandler(handler))
+}
+
+func (b *EventBus) publish(event any) {
+	for _, handler := range b.handlers {
+		handler(event)
+	}
+}
+
+func (g *Engine) subscribe() {
+	Subscribe(&g.Events, g.statsOnResource)
+	Subscribe(&g.Events, g.statsOnCycle)
+	Subscribe(&g.Events, g.statsOnPurchase)
+	Subscribe(&g.Events, g.statsOnUpgrade)
+	Subscribe(&g.Events, func(event CycleCompleted) {
+		g.complete(event.Industry, event.Worker, event.Resource, event.Amount)
+	})
+	Subscribe(&g.Events, func(event Ticked) { g.checkAchievements(event.At) })
+	g.subscribeUndo()
+	g.subscribeScripts()
+	g.subscribePlugins()
+	g.subscribeLog()
+	g.Events.handlers = append(g.Events.handlers, g.observers...)
+}
+
+func (g *Engine) ReplaceWith(fresh *Engine) {
+	logger, ghost, observers, format, hardcore := g.Logger, g.ghost, g.observers, g.SaveFormat, g.Hardcore
+	if g.pluginRuntime != fresh.pluginRuntime {
+		g.closePlugins()
+	}
+	*g = *fresh
+	g.Logger, g.ghost, g.observers, g.SaveFormat, g.Hardcore = logger, ghost, observers, format, hardcore
+	g.Events = EventBus{}
+	g.subscribe()
+	g.WorkersChanged()
+}
+
+func (g *Engine) changeResource(resource string, delta int) {
+	g.Resources[resource] += delta
+	g.Events.publish(ResourceChanged{At: g.LastUpdate, Resource: resource, Delta: delta})
+}
+
+func (g *Engine) spend(cost map[string]int) {
+	for resource, amount := range cost {
+		g.changeResource(resource, -amount)
+	}
+}
+
+func (g *Engine) statsOnResource(event ResourceChanged) {
+	if event.Delta > 0 {
+		g.Stats.recordEarned(event.Resource, event.Delta)
+	}
+}
+
+func (g *Engine) statsOnCycle(event CycleCompleted) {
+	industry := g.Industries[event.Industry]
+	g.Stats.recordCycle(industry.Key, industry.Workers[event.Worker].Definition.Key, event.Amount)
+}
+
+func (g *Engine) statsOnPurchase(event WorkerPurchased) {
+	if event.Cost != nil {
+		g.recordPurchase(purchaseBuy, event.Industry, event.Worker, event.Count, event.Cost)
+	}
+}
+
+func (g *Engine) statsOnUpgrade(event TierUpgraded) {
+	if event.Cost != nil {
+		g.recordPurchase(purchaseUpgrade, event.Industry, event.Worker, 1, event.Cost)
+	}
+}
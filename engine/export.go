@@ -0,0 +1,138 @@
+package engine
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type StatsExport struct {
+	GeneratedAt time.Time          `json:"generatedAt"`
+	Resources   map[string]int     `json:"resources"`
+	Rates       map[string]float64 `json:"rates"`
+	Workers     []WorkerExport     `json:"workers"`
+	Lifetime    LifetimeExport     `json:"lifetime"`
+}
+
+type WorkerExport struct {
+	Industry string `json:"industry"`
+	Worker   string `json:"worker"`
+	Name     string `json:"name"`
+	Owned    int    `json:"owned"`
+	Tier     int    `json:"tier"`
+	Auto     bool   `json:"auto"`
+	Running  bool   `json:"running"`
+	Cycles   int    `json:"cycles"`
+}
+
+type LifetimeExport struct {
+	StartedAt  time.Time         `json:"startedAt"`
+	Earned     map[string]int    `json:"earned"`
+	Spent      map[string]int    `json:"spent"`
+	Purchases  int               `json:"purchases"`
+	Milestones []MilestoneRecord `json:"milestones"`
+}
+
+func (g *Engine) ExportStats(now time.Time) StatsExport {
+	export := StatsExport{
+		GeneratedAt: now,
+		Resources:   CopyResources(g.Resources),
+		Rates:       g.Rates(),
+		Lifetime: LifetimeExport{
+			StartedAt:  g.Stats.StartedAt,
+			Earned:     CopyResources(g.Stats.Earned),
+			Spent:      CopyResources(g.Stats.Spent),
+			Purchases:  len(g.Stats.Purchases),
+			Milestones: g.Stats.Milestones,
+		},
+	}
+	for _, industry := range g.Industries {
+		for _, worker := range industry.Workers {
+			export.Workers = append(export.Workers, WorkerExport{
+				Industry: industry.Key,
+				Worker:   worker.Definition.Key,
+				Name:     worker.Definition.WorkerName,
+				Owned:    worker.Owned,
+				Tier:     worker.Tier,
+				Auto:     worker.Auto,
+				Running:  worker.Running,
+				Cycles:   g.Stats.Cycles[WorkerStatsKey(industry.Key, worker.Definition.Key)],
+			})
+		}
+	}
+	return export
+}
+
+func WriteStats(w io.Writer, export StatsExport, format string) error {
+	switch format {
+	case "json", "":
+		payload, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			return fmt.Errorf("serialize stats: %w", err)
+		}
+		_, err = w.Write(append(payload, '\n'))
+		return err
+	case "csv":
+		return writeStatsCSV(w, export)
+	default:
+		return fmt.Errorf("unknown stats format %q", format)
+	}
+}
+
+func writeStatsCSV(w io.Writer, export StatsExport) error {
+	out := csv.NewWriter(w)
+	rows := [][]string{{"section", "key", "metric", "value"}}
+	for _, resource := range SortedKeys(export.Resources) {
+		rows = append(rows,
+			[]string{"resource", resource, "amount", strconv.Itoa(export.Resources[resource])},
+			[]string{"resource", resource, "rate", strconv.FormatFloat(export.Rates[resource], 'f', 3, 64)},
+			[]string{"resource", resource, "earned", strconv.Itoa(export.Lifetime.Earned[resource])},
+			[]string{"resource", resource, "spent", strconv.Itoa(export.Lifetime.Spent[resource])},
+		)
+	}
+	for _, worker := range export.Workers {
+		key := WorkerStatsKey(worker.Industry, worker.Worker)
+		rows = append(rows,
+			[]string{"worker", key, "owned", strconv.Itoa(worker.Owned)},
+			[]string{"worker", key, "tier", strconv.Itoa(worker.Tier)},
+			[]string{"worker", key, "auto", strconv.FormatBool(worker.Auto)},
+			[]string{"worker", key, "running", strconv.FormatBool(worker.Running)},
+			[]string{"worker", key, "cycles", strconv.Itoa(worker.Cycles)},
+		)
+	}
+	rows = append(rows,
+		[]string{"lifetime", "run", "startedAt", export.Lifetime.StartedAt.Format(time.RFC3339)},
+		[]string{"lifetime", "run", "purchases", strconv.Itoa(export.Lifetime.Purchases)},
+		[]string{"lifetime", "run", "milestones", strconv.Itoa(len(export.Lifetime.Milestones))},
+	)
+	if err := out.WriteAll(rows); err != nil {
+		return fmt.Errorf("write stats csv: %w", err)
+	}
+	return nil
+}
+
+func WriteStatsFile(path string, export StatsExport, format string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create stats file: %w", err)
+	}
+	defer file.Close()
+	if err := WriteStats(file, export, format); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func SortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+var numberSuffixes = []string{"", "K", "M", "B", "T", "Qa", "Qi", "Sx", "Sp", "Oc", "No", "Dc"}
+
+func FormatNumber(value int, scientific bool) string {
+	if value > -1000 && value < 1000 {
+		return strconv.Itoa(value)
+	}
+	sign := ""
+	magnitude := math.Abs(float64(value))
+	if value < 0 {
+		sign = "-"
+	}
+	if scientific {
+		mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(magnitude, 'e', 2, 64), "e")
+		power, _ := strconv.Atoi(exponent)
+		return sign + mantissa + "e" + strconv.Itoa(power)
+	}
+	exponent := int(math.Floor(math.Log10(magnitude) / 3))
+	if exponent >= len(numberSuffixes) {
+		exponent = len(numberSuffixes) - 1
+	}
+	scaled := magnitude / math.Pow(1000, float64(exponent))
+	if scaled >= 999.5 && exponent < len(numberSuffixes)-1 {
+		exponent++
+		scaled /= 1000
+	}
+	return sign + TrimDecimals(scaled) + numberSuffixes[exponent]
+}
+
+func TrimDecimals(value float64) string {
+	precision := 2
+	switch {
+	case value >= 100:
+		precision = 0
+	case value >= 10:
+		precision = 1
+	}
+	text := strconv.FormatFloat(value, 'f', precision, 64)
+	if strings.Contains(text, ".") {
+		text = strings.TrimRight(strings.TrimRight(text, "0"), ".")
+	}
+	return text
+}
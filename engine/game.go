@@ -0,0 +1,749 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+
+	"archuser.org/go-game/config"
+)
+
+const (
+	MaxIndustries = 5
+	MaxQuantity   = 999999999
+	upkeepWarning = 10 * time.Second
+)
+
+var ownedMilestoneSteps = []int{10, 25, 50}
+
+type Engine struct {
+	Industries    []IndustryState
+	Resources     map[string]int
+	Production    []PassiveProductionState
+	Upkeep        []PassiveProductionState
+	Values        map[string]float64
+	Icons         map[string]config.IconConfig
+	BuyModeMax    bool
+	DevMode       bool
+	Hardcore      bool
+	Stats         Statistics
+	History       ResourceHistory
+	LastUpdate    time.Time
+	revision      int
+	savedAt       time.Time
+	Notices       []Notice
+	completed     []Completion
+	replay        *Replay
+	playback      *playback
+	Remote        func(kind string, industryIndex, workerIndex, count int) Status
+	scripts       []*script
+	plugins       []*plugin
+	pluginRuntime wazero.Runtime
+	undo          undoHistory
+	Logger        *slog.Logger
+	ghost         *GhostRun
+	observers     []func(any)
+	Seed          uint64
+	pcg           *rand.PCG
+	rng           *rand.Rand
+	SaveFormat    string
+	calendar      config.CalendarConfig
+	CalendarTime  time.Duration
+	Events        EventBus
+	clock         func() time.Time
+}
+
+type Options struct {
+	Start time.Time
+	Seed  uint64
+	Clock func() time.Time
+}
+
+type IndustryState struct {
+	Key      string
+	Name     string
+	Resource string
+	Workers  []WorkerState
+}
+
+type WorkerState struct {
+	Definition config.WorkerConfig
+	Owned      int
+	Tier       int
+	Running    bool
+	EndsAt     time.Time
+	Auto       bool
+}
+
+type PassiveProductionState struct {
+	Definition config.PassiveProductionSpec
+	NextAt     time.Time
+	warned     bool
+}
+
+type SaveGame struct {
+	Industries []saveIndustry   `json:"industries"`
+	Resources  map[string]int   `json:"resources"`
+	Production []saveProduction `json:"production"`
+	Upkeep     []saveProduction `json:"upkeep,omitempty"`
+	BuyModeMax bool             `json:"buyModeMax"`
+	DevMode    bool             `json:"devMode"`
+	Stats      *Statistics      `json:"stats,omitempty"`
+	SavedAt    time.Time        `json:"savedAt"`
+	Clock      time.Time        `json:"clock,omitempty"`
+	Calendar   time.Duration    `json:"calendar,omitempty"`
+	Seed       uint64           `json:"seed,omitempty"`
+	RNG        []byte           `json:"rng,omitempty"`
+	Build      *BuildInfo       `json:"build,omitempty"`
+	Version    int              `json:"version"`
+}
+
+type saveIndustry struct {
+	Key     string       `json:"key"`
+	Workers []saveWorker `json:"workers"`
+}
+
+type saveWorker struct {
+	Key   string `json:"key"`
+	Owned int    `json:"owned"`
+	Tier  int    `json:"tier"`
+	Auto  bool   `json:"auto"`
+}
+
+type saveProduction struct {
+	NextAt time.Time `json:"nextAt"`
+}
+
+func New(cfg config.GameConfig, options Options) (*Engine, error) {
+	resources := make(map[string]int)
+	for key, value := range cfg.StartingResources {
+		resources[key] = value
+	}
+
+	industries := make([]IndustryState, 0, len(cfg.Industries))
+	for _, industry := range cfg.Industries {
+		workers := make([]WorkerState, 0, len(industry.Workers))
+		for index, worker := range industry.Workers {
+			owned := 0
+			if index == 0 {
+				owned = 1
+			}
+			workers = append(workers, WorkerState{
+				Definition: worker,
+				Owned:      owned,
+				Tier:       1,
+			})
+		}
+		industries = append(industries, IndustryState{
+			Key:      industry.Key,
+			Name:     industry.Name,
+			Resource: industry.Resource,
+			Workers:  workers,
+		})
+	}
+
+	if len(industries) > MaxIndustries {
+		return nil, fmt.Errorf("too many industries: %d (max %d)", len(industries), MaxIndustries)
+	}
+
+	scripts, err := loadScripts(cfg.Dir, cfg.Scripts)
+	if err != nil {
+		return nil, err
+	}
+
+	now := options.Start
+	g := &Engine{
+		Industries: industries,
+		Resources:  resources,
+		Production: buildPassiveProduction(cfg.StartingProduction, now),
+		Upkeep:     buildPassiveProduction(cfg.Upkeep, now),
+		Values:     cfg.ResourceValues,
+		Icons:      cfg.ResourceIcons,
+		BuyModeMax: false,
+		Stats:      newStatistics(now),
+		History:    newResourceHistory(),
+		LastUpdate: now,
+		scripts:    scripts,
+		calendar:   newCalendar(cfg.Calendar),
+		clock:      options.Clock,
+	}
+	g.UseSeed(options.Seed)
+	g.subscribe()
+	if err := g.loadPlugins(cfg.Dir, cfg.Plugins); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func NewFromFile(path string, options Options) (*Engine, error) {
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return New(cfg, options)
+}
+
+func RealTime() Options {
+	return Options{Start: time.Now(), Seed: rand.Uint64(), Clock: time.Now}
+}
+
+func (g *Engine) Revision() int {
+	return g.revision
+}
+
+func (g *Engine) Now() time.Time {
+	return g.LastUpdate
+}
+
+func (g *Engine) wallTime() time.Time {
+	if g.clock == nil {
+		return g.Now()
+	}
+	return g.clock()
+}
+
+func (g *Engine) Update(now time.Time) {
+	if g.LastUpdate.IsZero() || now.Before(g.LastUpdate) {
+		g.LastUpdate = now
+	}
+	g.Step(now.Sub(g.LastUpdate))
+}
+
+func (g *Engine) Step(elapsed time.Duration) {
+	elapsed = max(elapsed, 0)
+	now := g.LastUpdate.Add(elapsed)
+	g.record(ReplayEvent{Kind: replayTick}, now)
+	g.Stats.Playtime += elapsed
+	g.LastUpdate = now
+	for index := range g.Production {
+		production := &g.Production[index]
+		if produced := production.apply(now); produced > 0 {
+			g.changeResource(production.Definition.Resource, produced)
+		}
+	}
+	g.chargeUpkeep(now)
+	for industryIndex := range g.Industries {
+		industry := &g.Industries[industryIndex]
+		for workerIndex := range industry.Workers {
+			worker := &industry.Workers[workerIndex]
+			if worker.Owned > 0 && elapsed > 0 {
+				g.Stats.recordWork(industry.Key, worker.Definition.Key, elapsed, worker.Running)
+			}
+			if worker.Auto && !worker.Running && worker.Owned > 0 {
+				worker.Running = true
+				worker.EndsAt = now.Add(worker.Definition.ProdRate)
+				g.workerChanged(industryIndex, workerIndex)
+			}
+			if !worker.Running {
+				continue
+			}
+			if now.Before(worker.EndsAt) {
+				continue
+			}
+			resource, amount := g.applyProduction(industryIndex, worker)
+			g.Events.publish(CycleCompleted{At: now, Industry: industryIndex, Worker: workerIndex, Resource: resource, Amount: amount})
+			worker.Running = false
+			if worker.Auto {
+				worker.Running = true
+				worker.EndsAt = now.Add(worker.Definition.ProdRate)
+			}
+			g.workerChanged(industryIndex, workerIndex)
+		}
+	}
+	for _, milestone := range g.Stats.observe(now, g.Resources) {
+		g.Notify(NoticeMilestone, tr("%s reached %s", milestone.Resource, FormatNumber(milestone.Amount, false)))
+		g.compareGhost(milestone)
+	}
+	g.advanceCalendar(elapsed, now)
+	g.Events.publish(Ticked{At: now, Elapsed: elapsed})
+	g.History.record(now, g.Resources)
+}
+
+func (g *Engine) StartRun(industryIndex, workerIndex int, now time.Time) Status {
+	g.record(ReplayEvent{Kind: ReplayRun, Industry: industryIndex, Worker: workerIndex}, now)
+	if g.Remote != nil {
+		return g.Remote(ReplayRun, industryIndex, workerIndex, 0)
+	}
+	worker := &g.Industries[industryIndex].Workers[workerIndex]
+	if worker.Owned == 0 {
+		return ErrorStatus(tr("need at least 1 worker"))
+	}
+	if worker.Running {
+		return ErrorStatus(tr("already running"))
+	}
+	worker.Running = true
+	worker.EndsAt = now.Add(worker.Definition.ProdRate)
+	g.revision++
+	g.workerChanged(industryIndex, workerIndex)
+	return SuccessStatus(tr("cycle started"))
+}
+
+func (g *Engine) PlanBuy(industryIndex, workerIndex int) (int, map[string]int) {
+	cost := g.Industries[industryIndex].Workers[workerIndex].Definition.Cost
+	count := 1
+	if g.BuyModeMax {
+		count = MaxAffordable(cost, g.Resources)
+		if g.DevMode && count < 1 {
+			count = 1
+		}
+	} else if !g.DevMode && !CanAfford(cost, g.Resources) {
+		count = 0
+	}
+	return count, MultiplyCost(cost, count)
+}
+
+func nextOwnedMilestone(owned int) int {
+	for scale := 1; ; scale *= 10 {
+		for _, step := range ownedMilestoneSteps {
+			if target := step * scale; target > owned {
+				return target
+			}
+		}
+	}
+}
+
+func (g *Engine) PlanMilestone(industryIndex, workerIndex int) (int, int, map[string]int) {
+	worker := g.Industries[industryIndex].Workers[workerIndex]
+	target := nextOwnedMilestone(worker.Owned)
+	count := target - worker.Owned
+	return target, count, MultiplyCost(worker.Definition.Cost, count)
+}
+
+func (g *Engine) BuyWorker(industryIndex, workerIndex int) Status {
+	count, _ := g.PlanBuy(industryIndex, workerIndex)
+	return g.BuyCount(industryIndex, workerIndex, count)
+}
+
+func (g *Engine) BuyCount(industryIndex, workerIndex, count int) Status {
+	g.record(ReplayEvent{Kind: replayBuy, Industry: industryIndex, Worker: workerIndex, Count: count}, g.Now())
+	if g.Remote != nil {
+		return g.Remote(replayBuy, industryIndex, workerIndex, count)
+	}
+	if count < 0 || count > MaxQuantity {
+		return ErrorStatus(tr("buy between 1 and %d at a time", MaxQuantity))
+	}
+	worker := &g.Industries[industryIndex].Workers[workerIndex]
+	total := MultiplyCost(worker.Definition.Cost, count)
+	if count == 0 || (!g.DevMode && !CanAfford(total, g.Resources)) {
+		return ErrorStatus(tr("cannot afford"))
+	}
+	var paid map[string]int
+	if !g.DevMode {
+		g.spend(total)
+		paid = total
+	}
+	worker.Owned += count
+	g.revision++
+	g.Events.publish(WorkerPurchased{At: g.LastUpdate, Industry: industryIndex, Worker: workerIndex, Count: count, Cost: paid})
+	g.workerChanged(industryIndex, workerIndex)
+	return SuccessStatus(tr("bought %s %s", FormatNumber(count, false), worker.Definition.WorkerName))
+}
+
+func (g *Engine) UpgradeCost(industryIndex, workerIndex int) map[string]int {
+	worker := g.Industries[industryIndex].Workers[workerIndex]
+	return ScaledCost(worker.Definition.Cost, worker.Definition.UpgradeMult, worker.Tier)
+}
+
+func (g *Engine) CanAffordUpgrade(industryIndex, workerIndex int) bool {
+	worker := g.Industries[industryIndex].Workers[workerIndex]
+	factor := scaleFactor(worker.Definition.UpgradeMult, worker.Tier)
+	for resource, amount := range worker.Definition.Cost {
+		if g.Resources[resource] < scaleAmount(amount, factor) {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *Engine) UpgradeWorker(industryIndex, workerIndex int) Status {
+	g.record(ReplayEvent{Kind: replayUpgrade, Industry: industryIndex, Worker: workerIndex}, g.Now())
+	if g.Remote != nil {
+		return g.Remote(replayUpgrade, industryIndex, workerIndex, 0)
+	}
+	worker := &g.Industries[industryIndex].Workers[workerIndex]
+	cost := g.UpgradeCost(industryIndex, workerIndex)
+	if !g.DevMode && !CanAfford(cost, g.Resources) {
+		return ErrorStatus(tr("cannot afford upgrade"))
+	}
+	var paid map[string]int
+	if !g.DevMode {
+		g.spend(cost)
+		paid = cost
+	}
+	worker.Tier++
+	g.revision++
+	g.Events.publish(TierUpgraded{At: g.LastUpdate, Industry: industryIndex, Worker: workerIndex, Tier: worker.Tier, Cost: paid})
+	if worker.Definition.AutoTier > 0 && worker.Tier >= worker.Definition.AutoTier && !worker.Auto {
+		worker.Auto = true
+		g.Notify(NoticeUnlock, tr("%s now runs automatically", worker.Definition.WorkerName))
+	}
+	g.workerChanged(industryIndex, workerIndex)
+	return SuccessStatus(tr("upgraded %s to tier %d", worker.Definition.WorkerName, worker.Tier))
+}
+
+func (g *Engine) recordPurchase(kind string, industryIndex, workerIndex, count int, cost map[string]int) {
+	industry := g.Industries[industryIndex]
+	g.Stats.recordPurchase(PurchaseRecord{
+		At:       g.LastUpdate,
+		Kind:     kind,
+		Industry: industry.Key,
+		Worker:   industry.Workers[workerIndex].Definition.Key,
+		Count:    count,
+		Cost:     cost,
+	})
+}
+
+func (g *Engine) Rates() map[string]float64 {
+	rates := make(map[string]float64)
+	for _, production := range g.Production {
+		spec := production.Definition
+		if spec.ProdRate > 0 {
+			rates[spec.Resource] += float64(spec.ProdQuant) / spec.ProdRate.Seconds()
+		}
+	}
+	for _, industry := range g.Industries {
+		for _, worker := range industry.Workers {
+			if !worker.Auto || worker.Owned == 0 || worker.Definition.ProdRate <= 0 {
+				continue
+			}
+			if _, ok := FindWorkerIndex(industry.Workers, worker.Definition.Produces); ok {
+				continue
+			}
+			rates[worker.Definition.Produces] += float64(worker.Definition.ProdQuant*worker.Owned) / worker.Definition.ProdRate.Seconds()
+		}
+	}
+	return rates
+}
+
+func (g *Engine) NetWorth() int {
+	return int(math.Round(g.costValue(g.Resources)))
+}
+
+func (g *Engine) Bankrupt() bool {
+	for _, amount := range g.Resources {
+		if amount < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Engine) applyProduction(industryIndex int, worker *WorkerState) (string, int) {
+	if worker.Owned == 0 {
+		return "", 0
+	}
+	industry := &g.Industries[industryIndex]
+	produced := worker.Definition.ProdQuant * worker.Owned
+	if targetIndex, ok := FindWorkerIndex(industry.Workers, worker.Definition.Produces); ok {
+		target := &industry.Workers[targetIndex]
+		target.Owned += produced
+		g.workerChanged(industryIndex, targetIndex)
+		return target.Definition.WorkerName, produced
+	}
+	g.changeResource(worker.Definition.Produces, produced)
+	return worker.Definition.Produces, produced
+}
+
+func CanAfford(cost, resources map[string]int) bool {
+	for resource, amount := range cost {
+		if resources[resource] < amount {
+			return false
+		}
+	}
+	return true
+}
+
+func MaxAffordable(cost, resources map[string]int) int {
+	limit := math.MaxInt
+	for resource, amount := range cost {
+		if amount <= 0 {
+			continue
+		}
+		limit = MinInt(limit, resources[resource]/amount)
+	}
+	if limit == math.MaxInt {
+		return 0
+	}
+	return limit
+}
+
+func MultiplyCost(cost map[string]int, count int) map[string]int {
+	total := make(map[string]int, len(cost))
+	for resource, amount := range cost {
+		total[resource] = saturatingMul(amount, count)
+	}
+	return total
+}
+
+func saturatingMul(amount, count int) int {
+	if amount > 0 && count > math.MaxInt/amount {
+		return math.MaxInt
+	}
+	return amount * count
+}
+
+func ScaledCost(base map[string]int, multiplier float64, tier int) map[string]int {
+	cost := make(map[string]int, len(base))
+	factor := scaleFactor(multiplier, tier)
+	for resource, amount := range base {
+		cost[resource] = scaleAmount(amount, factor)
+	}
+	return cost
+}
+
+func scaleFactor(multiplier float64, tier int) float64 {
+	return math.Pow(multiplier, float64(MaxInt(tier-1, 0)))
+}
+
+func scaleAmount(amount int, factor float64) int {
+	return int(math.Ceil(float64(amount) * factor))
+}
+
+func FindWorkerIndex(workers []WorkerState, key string) (int, bool) {
+	for index, worker := range workers {
+		if worker.Definition.Key == key {
+			return index, true
+		}
+	}
+	return 0, false
+}
+
+func MinInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func MaxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func (g *Engine) ResourceSummary(format func(int) string) []string {
+	if len(g.Resources) == 0 {
+		return []string{"no resources"}
+	}
+	keys := make([]string, 0, len(g.Resources))
+	for key := range g.Resources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("%s: %s", key, format(g.Resources[key])))
+	}
+	return lines
+}
+
+func buildPassiveProduction(definitions []config.PassiveProductionSpec, now time.Time) []PassiveProductionState {
+	if len(definitions) == 0 {
+		return nil
+	}
+	production := make([]PassiveProductionState, 0, len(definitions))
+	for _, definition := range definitions {
+		production = append(production, PassiveProductionState{
+			Definition: definition,
+			NextAt:     now.Add(definition.ProdRate),
+		})
+	}
+	return production
+}
+
+func saveProductions(states []PassiveProductionState) []saveProduction {
+	saved := make([]saveProduction, 0, len(states))
+	for _, entry := range states {
+		saved = append(saved, saveProduction{
+			NextAt: entry.NextAt,
+		})
+	}
+	return saved
+}
+
+func restoreProductions(states []PassiveProductionState, saved []saveProduction, anchor, now time.Time) {
+	for index := range states {
+		savedNextAt := saved[index].NextAt
+		if anchor.IsZero() {
+			states[index].NextAt = savedNextAt
+			continue
+		}
+		offset := savedNextAt.Sub(anchor)
+		if offset < 0 {
+			offset = 0
+		}
+		states[index].NextAt = now.Add(offset)
+	}
+}
+
+func (p *PassiveProductionState) apply(now time.Time) int {
+	if now.Before(p.NextAt) {
+		return 0
+	}
+	if p.Definition.ProdRate <= 0 || p.Definition.ProdQuant <= 0 {
+		return 0
+	}
+	intervals := now.Sub(p.NextAt)/p.Definition.ProdRate + 1
+	p.NextAt = p.NextAt.Add(intervals * p.Definition.ProdRate)
+	return int(intervals) * p.Definition.ProdQuant
+}
+
+func (g *Engine) chargeUpkeep(now time.Time) {
+	if !g.Hardcore {
+		return
+	}
+	for index := range g.Upkeep {
+		upkeep := &g.Upkeep[index]
+		resource := upkeep.Definition.Resource
+		if due := upkeep.apply(now); due > 0 {
+			upkeep.warned = false
+			g.changeResource(resource, -due)
+			continue
+		}
+		if upkeep.warned || upkeep.NextAt.Sub(now) > upkeepWarning || g.Resources[resource] >= upkeep.Definition.ProdQuant {
+			continue
+		}
+		upkeep.warned = true
+		g.Notify(NoticeStatus, tr("upkeep of %s %s due soon", FormatNumber(upkeep.Definition.ProdQuant, false), resource))
+	}
+}
+
+func (g *Engine) Restore(snapshot SaveGame) error {
+	state, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("serialize replay state: %w", err)
+	}
+	if err := g.applySnapshot(snapshot); err != nil {
+		return fmt.Errorf("apply save: %w", err)
+	}
+	g.record(ReplayEvent{Kind: replayState, State: state}, g.Now())
+	return nil
+}
+
+func (g *Engine) Capture() SaveGame {
+	industries := make([]saveIndustry, 0, len(g.Industries))
+	for _, industry := range g.Industries {
+		workers := make([]saveWorker, 0, len(industry.Workers))
+		for _, worker := range industry.Workers {
+			workers = append(workers, saveWorker{
+				Key:   worker.Definition.Key,
+				Owned: worker.Owned,
+				Tier:  worker.Tier,
+				Auto:  worker.Auto,
+			})
+		}
+		industries = append(industries, saveIndustry{
+			Key:     industry.Key,
+			Workers: workers,
+		})
+	}
+
+	production := saveProductions(g.Production)
+
+	resources := make(map[string]int, len(g.Resources))
+	for key, value := range g.Resources {
+		resources[key] = value
+	}
+
+	build := CurrentBuild()
+	return SaveGame{
+		Industries: industries,
+		Resources:  resources,
+		Production: production,
+		Upkeep:     saveProductions(g.Upkeep),
+		BuyModeMax: g.BuyModeMax,
+		DevMode:    g.DevMode,
+		Stats:      &g.Stats,
+		SavedAt:    g.wallTime(),
+		Clock:      g.Now(),
+		Calendar:   g.CalendarTime,
+		Seed:       g.Seed,
+		RNG:        g.rngState(),
+		Build:      &build,
+		Version:    1,
+	}
+}
+
+func (g *Engine) applySnapshot(snapshot SaveGame) error {
+	if snapshot.Resources == nil {
+		return fmt.Errorf("save missing resources")
+	}
+	if len(snapshot.Industries) != len(g.Industries) {
+		return fmt.Errorf("save industries mismatch")
+	}
+	if len(snapshot.Production) != len(g.Production) {
+		return fmt.Errorf("save production mismatch")
+	}
+
+	industryLookup := make(map[string]saveIndustry, len(snapshot.Industries))
+	for _, industry := range snapshot.Industries {
+		industryLookup[industry.Key] = industry
+	}
+
+	for industryIndex := range g.Industries {
+		industry := &g.Industries[industryIndex]
+		savedIndustry, ok := industryLookup[industry.Key]
+		if !ok {
+			return fmt.Errorf("save missing industry %s", industry.Key)
+		}
+
+		workerLookup := make(map[string]saveWorker, len(savedIndustry.Workers))
+		for _, worker := range savedIndustry.Workers {
+			workerLookup[worker.Key] = worker
+		}
+
+		for workerIndex := range industry.Workers {
+			worker := &industry.Workers[workerIndex]
+			savedWorker, ok := workerLookup[worker.Definition.Key]
+			if !ok {
+				return fmt.Errorf("save missing worker %s", worker.Definition.Key)
+			}
+			worker.Owned = savedWorker.Owned
+			worker.Tier = savedWorker.Tier
+			worker.Auto = savedWorker.Auto || (worker.Definition.AutoTier > 0 && savedWorker.Tier >= worker.Definition.AutoTier)
+			worker.Running = false
+			worker.EndsAt = time.Time{}
+		}
+	}
+
+	g.Resources = make(map[string]int, len(snapshot.Resources))
+	for key, value := range snapshot.Resources {
+		g.Resources[key] = value
+	}
+
+	now := g.Now()
+	anchor := snapshot.Clock
+	if anchor.IsZero() {
+		anchor = snapshot.SavedAt
+	}
+	restoreProductions(g.Production, snapshot.Production, anchor, now)
+	if len(snapshot.Upkeep) == len(g.Upkeep) {
+		restoreProductions(g.Upkeep, snapshot.Upkeep, anchor, now)
+	}
+
+	g.BuyModeMax = snapshot.BuyModeMax
+	g.DevMode = snapshot.DevMode
+	g.savedAt = snapshot.SavedAt
+	g.CalendarTime = snapshot.Calendar
+	g.History.reset()
+	g.undo = undoHistory{}
+	g.Stats = newStatistics(now)
+	if snapshot.Stats != nil {
+		g.Stats = *snapshot.Stats
+		g.Stats.normalize(now)
+	}
+	if snapshot.Seed != 0 || len(snapshot.RNG) > 0 {
+		if err := g.restoreRNG(snapshot.Seed, snapshot.RNG); err != nil {
+			return err
+		}
+	}
+	g.WorkersChanged()
+	return nil
+}
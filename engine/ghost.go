@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"fmt"
+	"time"
+)
+
+const noticeGhost = "ghost"
+
+type GhostRun struct {
+	Name        string
+	checkpoints map[ghostKey]time.Duration
+	order       []MilestoneRecord
+	startedAt   time.Time
+	split       time.Duration
+	hasSplit    bool
+}
+
+type ghostKey struct {
+	resource string
+	amount   int
+}
+
+func NewGhost(name string, stats *Statistics) (*GhostRun, error) {
+	if stats == nil || len(stats.Milestones) == 0 {
+		return nil, fmt.Errorf("ghost %s has no milestones", name)
+	}
+	ghost := &GhostRun{
+		Name:        name,
+		checkpoints: make(map[ghostKey]time.Duration),
+		order:       stats.Milestones,
+		startedAt:   stats.StartedAt,
+	}
+	for _, milestone := range ghost.order {
+		ghost.checkpoints[ghostKey{milestone.Resource, milestone.Amount}] = milestone.At.Sub(ghost.startedAt)
+	}
+	return ghost, nil
+}
+
+func (g *Engine) UseGhost(ghost *GhostRun) {
+	g.ghost = ghost
+}
+
+func (g *Engine) compareGhost(milestone MilestoneRecord) {
+	if g.ghost == nil {
+		return
+	}
+	theirs, ok := g.ghost.checkpoints[ghostKey{milestone.Resource, milestone.Amount}]
+	if !ok {
+		return
+	}
+	split := milestone.At.Sub(g.Stats.StartedAt) - theirs
+	g.ghost.split, g.ghost.hasSplit = split, true
+	label := fmt.Sprintf("%s %s", milestone.Resource, FormatNumber(milestone.Amount, false))
+	if split <= 0 {
+		g.Notify(noticeGhost, tr("%s: %s ahead of the ghost", label, (-split).Truncate(time.Second)))
+		return
+	}
+	g.Notify(noticeGhost, tr("%s: %s behind the ghost", label, split.Truncate(time.Second)))
+}
+
+func (g *Engine) GhostSplit() string {
+	if g.ghost == nil {
+		return ""
+	}
+	elapsed := g.Now().Sub(g.Stats.StartedAt)
+	for _, milestone := range g.ghost.order {
+		if g.Stats.Reached[milestone.Resource] >= milestone.Amount {
+			continue
+		}
+		if behind := elapsed - milestone.At.Sub(g.ghost.startedAt); behind > 0 {
+			return tr("ghost %s", formatSplit(behind))
+		}
+		break
+	}
+	if !g.ghost.hasSplit {
+		return tr("ghost %s", g.ghost.Name)
+	}
+	return tr("ghost %s", formatSplit(g.ghost.split))
+}
+
+func formatSplit(split time.Duration) string {
+	if split <= 0 {
+		return "-" + (-split).Truncate(time.Second).String()
+	}
+	return "+" + split.Truncate(time.Second).String()
+}
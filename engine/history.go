@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"time"
+)
+
+const (
+	HistoryInterval = time.Second
+	historyCapacity = 600
+)
+
+type ResourceHistory struct {
+	Interval time.Duration
+	Capacity int
+	Series   map[string][]int
+	lastAt   time.Time
+}
+
+func newResourceHistory() ResourceHistory {
+	return ResourceHistory{
+		Interval: HistoryInterval,
+		Capacity: historyCapacity,
+		Series:   make(map[string][]int),
+	}
+}
+
+func (h *ResourceHistory) record(now time.Time, resources map[string]int) {
+	if !h.lastAt.IsZero() && now.Sub(h.lastAt) < h.Interval {
+		return
+	}
+	h.lastAt = now
+	for resource, amount := range resources {
+		series := append(h.Series[resource], amount)
+		if len(series) > h.Capacity {
+			series = series[len(series)-h.Capacity:]
+		}
+		h.Series[resource] = series
+	}
+}
+
+func (h *ResourceHistory) Values(resource string, count int) []int {
+	series := h.Series[resource]
+	if count > 0 && len(series) > count {
+		return series[len(series)-count:]
+	}
+	return series
+}
+
+func (h *ResourceHistory) reset() {
+	h.Series = make(map[string][]int)
+	h.lastAt = time.Time{}
+}
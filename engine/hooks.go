@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"time"
+)
+
+type WorkerStateChanged struct {
+	At       time.Time
+	Industry int
+	Worker   int
+	Owned    int
+	Tier     int
+	Auto     bool
+	Running  bool
+}
+
+func (g *Engine) OnResourceChanged(handler func(ResourceChanged)) {
+	g.OnEvent(typedHandler(handler))
+}
+
+func (g *Engine) OnWorkerStateChanged(handler func(WorkerStateChanged)) {
+	g.OnEvent(typedHandler(handler))
+}
+
+func (g *Engine) OnEvent(handler func(any)) {
+	g.observers = append(g.observers, handler)
+	g.Events.handlers = append(g.Events.handlers, handler)
+}
+
+func typedHandler[E any](handler func(E)) func(any) {
+	return func(event any) {
+		if typed, ok := event.(E); ok {
+			handler(typed)
+		}
+	}
+}
+
+func (g *Engine) workerChanged(industryIndex, workerIndex int) {
+	worker := g.Industries[industryIndex].Workers[workerIndex]
+	g.Events.publish(WorkerStateChanged{
+		At:       g.LastUpdate,
+		Industry: industryIndex,
+		Worker:   workerIndex,
+		Owned:    worker.Owned,
+		Tier:     worker.Tier,
+		Auto:     worker.Auto,
+		Running:  worker.Running,
+	})
+}
+
+func (g *Engine) WorkersChanged() {
+	for industryIndex, industry := range g.Industries {
+		for workerIndex := range industry.Workers {
+			g.workerChanged(industryIndex, workerIndex)
+		}
+	}
+}
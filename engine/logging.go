@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+)
+
+const (
+	LogFormatJSON   = "json"
+	logFormatLogfmt = "logfmt"
+	PerfLogInterval = time.Minute
+)
+
+func OpenLogger(path, format, level string) (*slog.Logger, io.Closer, error) {
+	var threshold slog.Level
+	if err := threshold.UnmarshalText([]byte(level)); err != nil {
+		return nil, nil, fmt.Errorf("parse log level: %w", err)
+	}
+	handlers := map[string]func(io.Writer, *slog.HandlerOptions) slog.Handler{
+		LogFormatJSON:   func(w io.Writer, o *slog.HandlerOptions) slog.Handler { return slog.NewJSONHandler(w, o) },
+		logFormatLogfmt: func(w io.Writer, o *slog.HandlerOptions) slog.Handler { return slog.NewTextHandler(w, o) },
+	}
+	handler, ok := handlers[format]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown log format %q (json or logfmt)", format)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open log: %w", err)
+	}
+	return slog.New(handler(file, &slog.HandlerOptions{Level: threshold})), file, nil
+}
+
+func (g *Engine) UseLogger(logger *slog.Logger) {
+	g.Logger = logger
+	g.subscribeLog()
+}
+
+func (g *Engine) subscribeLog() {
+	if g.Logger == nil {
+		return
+	}
+	Subscribe(&g.Events, func(event WorkerPurchased) {
+		g.Logger.Info("purchase", g.workerAttrs(event.Industry, event.Worker, "count", event.Count, "cost", event.Cost)...)
+	})
+	Subscribe(&g.Events, func(event TierUpgraded) {
+		g.Logger.Info("upgrade", g.workerAttrs(event.Industry, event.Worker, "tier", event.Tier, "cost", event.Cost)...)
+	})
+	Subscribe(&g.Events, func(event CycleCompleted) {
+		g.Logger.Debug("cycle", g.workerAttrs(event.Industry, event.Worker, "resource", event.Resource, "amount", event.Amount)...)
+	})
+}
+
+func (g *Engine) workerAttrs(industryIndex, workerIndex int, attrs ...any) []any {
+	industry := g.Industries[industryIndex]
+	return append([]any{"industry", industry.Key, "worker", industry.Workers[workerIndex].Definition.Key}, attrs...)
+}
+
+func (g *Engine) logNotice(kind, message string) {
+	if g.Logger == nil {
+		return
+	}
+	level := slog.LevelInfo
+	if kind == noticeScript || kind == noticePlugin {
+		level = slog.LevelWarn
+	}
+	g.Logger.Log(context.Background(), level, "notice", "kind", kind, "message", message)
+}
+
+func AverageDuration(total time.Duration, count int) time.Duration {
+	if count == 0 {
+		return 0
+	}
+	return total / time.Duration(count)
+}
@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"time"
+)
+
+const (
+	NoticeUnlock      = "unlock"
+	NoticeMilestone   = "milestone"
+	NoticeStatus      = "status"
+	NoticeAchievement = "achievement"
+	maxNotices        = 100
+)
+
+type Notice struct {
+	At      time.Time
+	Kind    string
+	Message string
+}
+
+func (g *Engine) Notify(kind, message string) {
+	g.Notices = append(g.Notices, Notice{At: g.LastUpdate, Kind: kind, Message: message})
+	g.logNotice(kind, message)
+	if len(g.Notices) > maxNotices {
+		g.Notices = g.Notices[len(g.Notices)-maxNotices:]
+	}
+}
+
+func (g *Engine) TakeNotices() []Notice {
+	notices := g.Notices
+	g.Notices = nil
+	return notices
+}
+
+type Completion struct {
+	At       time.Time
+	Industry int
+	Worker   int
+	Resource string
+	Amount   int
+}
+
+func (g *Engine) complete(industry, worker int, resource string, amount int) {
+	if amount <= 0 {
+		return
+	}
+	g.completed = append(g.completed, Completion{At: g.LastUpdate, Industry: industry, Worker: worker, Resource: resource, Amount: amount})
+	if len(g.completed) > maxNotices {
+		g.completed = g.completed[len(g.completed)-maxNotices:]
+	}
+}
+
+func (g *Engine) TakeCompletions() []Completion {
+	completed := g.completed
+	g.completed = nil
+	return completed
+}
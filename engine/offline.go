@@ -0,0 +1,111 @@
+package engine
+
+import (
+	"time"
+)
+
+const (
+	maxOfflineDuration = 24 * time.Hour
+	MinOfflineDuration = time.Minute
+	offlinePassive     = "passive"
+)
+
+type OfflineEarning struct {
+	Source  string
+	Amounts map[string]int
+}
+
+type OfflineReport struct {
+	Elapsed  time.Duration
+	Credited time.Duration
+	Earnings []OfflineEarning
+	Upkeep   map[string]int
+}
+
+func (g *Engine) ApplyOffline(now time.Time) OfflineReport {
+	savedAt := g.savedAt
+	g.savedAt = time.Time{}
+	if savedAt.IsZero() || !now.After(savedAt) {
+		return OfflineReport{}
+	}
+	report := OfflineReport{Elapsed: now.Sub(savedAt), Credited: minDuration(now.Sub(savedAt), maxOfflineDuration)}
+	passive := make(map[string]int)
+	for _, production := range g.Production {
+		spec := production.Definition
+		if spec.ProdRate > 0 {
+			passive[spec.Resource] += g.earnOffline(spec.Resource, int(report.Credited/spec.ProdRate)*spec.ProdQuant)
+		}
+	}
+	report.add(offlinePassive, passive)
+	for index := range g.Industries {
+		report.add(g.Industries[index].Name, g.offlineIndustry(&g.Industries[index], report.Credited))
+	}
+	report.Upkeep = g.offlineUpkeep(report.Credited)
+	g.WorkersChanged()
+	g.advanceCalendar(report.Credited, now)
+	g.recordState()
+	return report
+}
+
+func (g *Engine) earnOffline(resource string, amount int) int {
+	g.changeResource(resource, amount)
+	return amount
+}
+
+func (g *Engine) offlineUpkeep(credited time.Duration) map[string]int {
+	if !g.Hardcore {
+		return nil
+	}
+	charged := make(map[string]int)
+	for _, upkeep := range g.Upkeep {
+		spec := upkeep.Definition
+		if spec.ProdRate <= 0 {
+			continue
+		}
+		due := int(credited/spec.ProdRate) * spec.ProdQuant
+		g.changeResource(spec.Resource, -due)
+		charged[spec.Resource] += due
+	}
+	return charged
+}
+
+func (g *Engine) offlineIndustry(industry *IndustryState, credited time.Duration) map[string]int {
+	amounts := make(map[string]int)
+	gains := make(map[int]int)
+	for _, worker := range industry.Workers {
+		if !worker.Auto || worker.Owned == 0 || worker.Definition.ProdRate <= 0 {
+			continue
+		}
+		produced := int(credited/worker.Definition.ProdRate) * worker.Definition.ProdQuant * worker.Owned
+		g.Stats.Output[WorkerStatsKey(industry.Key, worker.Definition.Key)] += produced
+		g.Stats.recordWork(industry.Key, worker.Definition.Key, credited, true)
+		if targetIndex, ok := FindWorkerIndex(industry.Workers, worker.Definition.Produces); ok {
+			gains[targetIndex] += produced
+			amounts[industry.Workers[targetIndex].Definition.WorkerName] += produced
+			continue
+		}
+		amounts[worker.Definition.Produces] += g.earnOffline(worker.Definition.Produces, produced)
+	}
+	for targetIndex, gain := range gains {
+		industry.Workers[targetIndex].Owned += gain
+	}
+	return amounts
+}
+
+func (r *OfflineReport) add(source string, amounts map[string]int) {
+	for key, amount := range amounts {
+		if amount <= 0 {
+			delete(amounts, key)
+		}
+	}
+	if len(amounts) > 0 {
+		r.Earnings = append(r.Earnings, OfflineEarning{Source: source, Amounts: amounts})
+	}
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,198 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+const (
+	pluginHostModule  = "go_game_v1"
+	pluginMemoryPages = 1024
+	pluginCallTimeout = 100 * time.Millisecond
+	pluginInitTimeout = time.Second
+	noticePlugin      = "plugin"
+	pluginLoad        = "on_load"
+	pluginTick        = "on_tick"
+	pluginPurchase    = "on_purchase"
+	pluginUpgrade     = "on_upgrade"
+	pluginCycle       = "on_cycle"
+	pluginDay         = "on_day"
+	pluginPanel       = "panel"
+	pluginInitialize  = "_initialize"
+)
+
+type plugin struct {
+	Name   string
+	module api.Module
+	Panel  []string
+	Failed bool
+}
+
+type pluginKey struct{}
+
+type pluginCall struct {
+	game   *Engine
+	plugin *plugin
+}
+
+func (g *Engine) loadPlugins(dir string, paths []string) error {
+	if len(paths) == 0 {
+		return nil
+	}
+	ctx := context.Background()
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().WithMemoryLimitPages(pluginMemoryPages).WithCloseOnContextDone(true))
+	g.pluginRuntime = runtime
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		g.closePlugins()
+		return fmt.Errorf("start plugin runtime: %w", err)
+	}
+	if err := hostModule(ctx, runtime); err != nil {
+		g.closePlugins()
+		return fmt.Errorf("start plugin runtime: %w", err)
+	}
+	for _, path := range paths {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		if err := g.loadPlugin(runtime, path); err != nil {
+			g.closePlugins()
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *Engine) closePlugins() {
+	if g.pluginRuntime == nil {
+		return
+	}
+	_ = g.pluginRuntime.Close(context.Background())
+	g.pluginRuntime = nil
+	g.plugins = nil
+}
+
+func (g *Engine) loadPlugin(runtime wazero.Runtime, path string) error {
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read plugin %s: %w", path, err)
+	}
+	current := &plugin{Name: filepath.Base(path)}
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), pluginKey{}, pluginCall{game: g, plugin: current}), pluginInitTimeout)
+	defer cancel()
+	module, err := runtime.InstantiateWithConfig(ctx, code, wazero.NewModuleConfig().WithName(current.Name).WithStartFunctions(pluginInitialize).WithRandSource(rngReader{g}))
+	if err != nil {
+		return fmt.Errorf("load plugin %s: %w", path, err)
+	}
+	current.module = module
+	g.plugins = append(g.plugins, current)
+	g.callPlugin(current, pluginLoad)
+	return nil
+}
+
+func hostModule(ctx context.Context, runtime wazero.Runtime) error {
+	_, err := runtime.NewHostModuleBuilder(pluginHostModule).
+		NewFunctionBuilder().WithFunc(pluginResource).Export("resource").
+		NewFunctionBuilder().WithFunc(pluginAdd).Export("add").
+		NewFunctionBuilder().WithFunc(pluginRegister).Export("register_resource").
+		NewFunctionBuilder().WithFunc(pluginNotify).Export("notify").
+		NewFunctionBuilder().WithFunc(pluginPanelLine).Export("panel_line").
+		NewFunctionBuilder().WithFunc(pluginRandom).Export("random").
+		Instantiate(ctx)
+	return err
+}
+
+func (g *Engine) callPlugin(current *plugin, export string, args ...uint64) bool {
+	function := current.module.ExportedFunction(export)
+	if function == nil || current.Failed {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), pluginKey{}, pluginCall{game: g, plugin: current}), pluginCallTimeout)
+	defer cancel()
+	if _, err := function.Call(ctx, args...); err != nil {
+		current.Failed = true
+		g.Notify(noticePlugin, tr("plugin %s disabled: %v", current.Name, err))
+		return false
+	}
+	return true
+}
+
+func (g *Engine) subscribePlugins() {
+	Subscribe(&g.Events, func(event Ticked) {
+		if event.Elapsed > 0 {
+			g.broadcastPlugins(pluginTick, api.EncodeI64(event.Elapsed.Milliseconds()))
+		}
+	})
+	Subscribe(&g.Events, func(event WorkerPurchased) {
+		g.broadcastPlugins(pluginPurchase, api.EncodeI32(int32(event.Industry)), api.EncodeI32(int32(event.Worker)), api.EncodeI64(int64(event.Count)))
+	})
+	Subscribe(&g.Events, func(event TierUpgraded) {
+		g.broadcastPlugins(pluginUpgrade, api.EncodeI32(int32(event.Industry)), api.EncodeI32(int32(event.Worker)), api.EncodeI64(int64(event.Tier)))
+	})
+	Subscribe(&g.Events, func(event DayStarted) {
+		g.broadcastPlugins(pluginDay, api.EncodeI64(int64(g.Day())))
+	})
+	Subscribe(&g.Events, func(event CycleCompleted) {
+		g.broadcastPlugins(pluginCycle, api.EncodeI32(int32(event.Industry)), api.EncodeI32(int32(event.Worker)), api.EncodeI64(int64(event.Amount)))
+	})
+}
+
+func (g *Engine) broadcastPlugins(export string, args ...uint64) {
+	for _, current := range g.plugins {
+		g.callPlugin(current, export, args...)
+	}
+}
+
+func (g *Engine) PluginPanels() []*plugin {
+	panels := make([]*plugin, 0, len(g.plugins))
+	for _, current := range g.plugins {
+		current.Panel = nil
+		if g.callPlugin(current, pluginPanel) || current.Failed {
+			panels = append(panels, current)
+		}
+	}
+	return panels
+}
+
+func pluginString(m api.Module, ptr, size uint32) string {
+	data, ok := m.Memory().Read(ptr, size)
+	if !ok {
+		panic(fmt.Errorf("string out of range: %d+%d", ptr, size))
+	}
+	return string(data)
+}
+
+func pluginContext(ctx context.Context) pluginCall {
+	return ctx.Value(pluginKey{}).(pluginCall)
+}
+
+func pluginResource(ctx context.Context, m api.Module, ptr, size uint32) int64 {
+	return int64(pluginContext(ctx).game.Resources[pluginString(m, ptr, size)])
+}
+
+func pluginAdd(ctx context.Context, m api.Module, ptr, size uint32, amount int64) {
+	pluginContext(ctx).game.changeResource(pluginString(m, ptr, size), int(amount))
+}
+
+func pluginRegister(ctx context.Context, m api.Module, ptr, size uint32, start int64) {
+	g := pluginContext(ctx).game
+	name := pluginString(m, ptr, size)
+	if _, ok := g.Resources[name]; !ok {
+		g.Resources[name] = int(start)
+	}
+}
+
+func pluginNotify(ctx context.Context, m api.Module, ptr, size uint32) {
+	pluginContext(ctx).game.Notify(noticePlugin, pluginString(m, ptr, size))
+}
+
+func pluginPanelLine(ctx context.Context, m api.Module, ptr, size uint32) {
+	current := pluginContext(ctx).plugin
+	current.Panel = append(current.Panel, pluginString(m, ptr, size))
+}
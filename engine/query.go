@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"time"
+)
+
+func (g *Engine) ProductionRate(resource string) float64 {
+	return g.Rates()[resource]
+}
+
+func (g *Engine) TimeToAfford(cost map[string]int) (time.Duration, bool) {
+	rates := g.Rates()
+	var longest time.Duration
+	for resource, amount := range cost {
+		short := amount - g.Resources[resource]
+		if short <= 0 {
+			continue
+		}
+		wait, ok := payback(float64(short), rates[resource])
+		if !ok {
+			return 0, false
+		}
+		longest = max(longest, wait)
+	}
+	return longest, true
+}
+
+func (g *Engine) ProjectResources(at time.Time) map[string]int {
+	seconds := max(at.Sub(g.Now()), 0).Seconds()
+	projected := CopyResources(g.Resources)
+	for resource, rate := range g.Rates() {
+		projected[resource] += int(rate * seconds)
+	}
+	return projected
+}
+
+func (g *Engine) affordSeconds(cost map[string]int) float64 {
+	wait, ok := g.TimeToAfford(cost)
+	if !ok {
+		return -1
+	}
+	return wait.Seconds()
+}
+
+func AffordLabel(wait time.Duration, ok bool) string {
+	if !ok {
+		return tr("never at current rates")
+	}
+	return wait.String()
+}
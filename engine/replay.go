@@ -0,0 +1,181 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	replayVersion = 1
+	replayTick    = "tick"
+	replayBuy     = "buy"
+	ReplayRun     = "run"
+	replayUpgrade = "upgrade"
+	replayState   = "state"
+)
+
+type ReplayEvent struct {
+	At       time.Duration   `json:"at"`
+	Kind     string          `json:"kind"`
+	Industry int             `json:"industry,omitempty"`
+	Worker   int             `json:"worker,omitempty"`
+	Count    int             `json:"count,omitempty"`
+	State    json.RawMessage `json:"state,omitempty"`
+}
+
+type Replay struct {
+	Version    int             `json:"version"`
+	RecordedAt time.Time       `json:"recordedAt"`
+	Start      json.RawMessage `json:"start"`
+	Events     []ReplayEvent   `json:"events"`
+	origin     time.Time
+}
+
+type playback struct {
+	replay *Replay
+	origin time.Time
+	next   int
+}
+
+func (g *Engine) StartRecording() error {
+	start, err := json.Marshal(g.Capture())
+	if err != nil {
+		return fmt.Errorf("serialize replay start: %w", err)
+	}
+	g.replay = &Replay{Version: replayVersion, RecordedAt: g.wallTime(), Start: start, origin: g.Now()}
+	return nil
+}
+
+func (g *Engine) Recording() bool {
+	return g.replay != nil
+}
+
+func (g *Engine) record(event ReplayEvent, at time.Time) {
+	if g.replay == nil {
+		return
+	}
+	event.At = at.Sub(g.replay.origin)
+	g.replay.Events = append(g.replay.Events, event)
+}
+
+func (g *Engine) recordState() {
+	if g.replay == nil {
+		return
+	}
+	state, err := json.Marshal(g.Capture())
+	if err != nil {
+		return
+	}
+	g.record(ReplayEvent{Kind: replayState, State: state}, g.Now())
+}
+
+func (g *Engine) SaveReplay(path string) error {
+	if g.replay == nil {
+		return nil
+	}
+	payload, err := json.Marshal(g.replay)
+	if err != nil {
+		return fmt.Errorf("serialize replay: %w", err)
+	}
+	if err := os.WriteFile(path, payload, 0o644); err != nil {
+		return fmt.Errorf("write replay: %w", err)
+	}
+	return nil
+}
+
+func LoadReplay(path string) (*Replay, error) {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read replay: %w", err)
+	}
+	var replay Replay
+	if err := json.Unmarshal(payload, &replay); err != nil {
+		return nil, fmt.Errorf("parse replay: %w", err)
+	}
+	if replay.Version > replayVersion {
+		return nil, fmt.Errorf("unsupported replay version %d", replay.Version)
+	}
+	return &replay, nil
+}
+
+func (g *Engine) StartPlayback(replay *Replay, origin time.Time) error {
+	if err := g.checkReplay(replay); err != nil {
+		return err
+	}
+	g.LastUpdate = origin
+	if err := g.applyState(replay.Start); err != nil {
+		return fmt.Errorf("apply replay start: %w", err)
+	}
+	g.replay = nil
+	g.playback = &playback{replay: replay, origin: origin}
+	return nil
+}
+
+func (g *Engine) checkReplay(replay *Replay) error {
+	for index, event := range replay.Events {
+		if event.Kind != replayBuy && event.Kind != ReplayRun && event.Kind != replayUpgrade {
+			continue
+		}
+		if event.Industry < 0 || event.Industry >= len(g.Industries) {
+			return fmt.Errorf("replay event %d: industry %d not in this config", index, event.Industry)
+		}
+		if event.Worker < 0 || event.Worker >= len(g.Industries[event.Industry].Workers) {
+			return fmt.Errorf("replay event %d: worker %d not in industry %s", index, event.Worker, g.Industries[event.Industry].Key)
+		}
+	}
+	return nil
+}
+
+func (g *Engine) Replaying() bool {
+	return g.playback != nil
+}
+
+func (g *Engine) PlaybackDone() bool {
+	return g.playback != nil && g.playback.next >= len(g.playback.replay.Events)
+}
+
+func (g *Engine) PlaybackUntil(now time.Time) []Status {
+	var statuses []Status
+	for !g.PlaybackDone() {
+		event := g.playback.replay.Events[g.playback.next]
+		at := g.playback.origin.Add(event.At)
+		if event.Kind == replayTick && at.After(now) {
+			break
+		}
+		g.playback.next++
+		if status := g.replayEvent(event, at); status.Message != "" {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+func (g *Engine) replayEvent(event ReplayEvent, at time.Time) Status {
+	switch event.Kind {
+	case replayTick:
+		g.Update(at)
+	case replayBuy:
+		return g.BuyCount(event.Industry, event.Worker, event.Count)
+	case ReplayRun:
+		return g.StartRun(event.Industry, event.Worker, at)
+	case replayUpgrade:
+		return g.UpgradeWorker(event.Industry, event.Worker)
+	case replayUndo:
+		return g.Undo()
+	case replayState:
+		if err := g.applyState(event.State); err != nil {
+			return ErrorStatus(tr("replay state failed: %v", err))
+		}
+	}
+	return Status{}
+}
+
+func (g *Engine) applyState(state json.RawMessage) error {
+	var snapshot SaveGame
+	if err := json.Unmarshal(state, &snapshot); err != nil {
+		return fmt.Errorf("parse state: %w", err)
+	}
+	return g.applySnapshot(snapshot)
+}
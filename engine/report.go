@@ -0,0 +1,151 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	reportSparkWidth  = 60
+	reportPurchaseRow = 50
+)
+
+var SparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+func WriteReport(path string, g *Engine, sessionStart, now time.Time) error {
+	if err := os.WriteFile(path, []byte(g.Report(sessionStart, now)), 0o644); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	return nil
+}
+
+func (g *Engine) Report(sessionStart, now time.Time) string {
+	var out strings.Builder
+	stats := g.Stats
+	fmt.Fprintf(&out, "# Go Game Run Report\n\n")
+	fmt.Fprintf(&out, "- Generated: %s\n", now.Format(time.RFC1123))
+	fmt.Fprintf(&out, "- Run length: %s\n", now.Sub(stats.StartedAt).Truncate(time.Second))
+	fmt.Fprintf(&out, "- Session length: %s\n", now.Sub(sessionStart).Truncate(time.Second))
+	fmt.Fprintf(&out, "- Purchases: %d\n\n", len(stats.Purchases))
+
+	fmt.Fprintf(&out, "## Totals\n\n")
+	fmt.Fprintf(&out, "| Resource | Current | Earned | Spent |\n|---|---:|---:|---:|\n")
+	for _, resource := range reportResources(g) {
+		fmt.Fprintf(&out, "| %s | %d | %d | %d |\n", resource, g.Resources[resource], stats.Earned[resource], stats.Spent[resource])
+	}
+
+	fmt.Fprintf(&out, "\n## Resource Growth\n\n")
+	if len(stats.Samples) < 2 {
+		fmt.Fprintf(&out, "Not enough samples yet.\n")
+	} else {
+		fmt.Fprintf(&out, "```\n")
+		for _, resource := range reportResources(g) {
+			values := make([]int, 0, len(stats.Samples))
+			for _, sample := range stats.Samples {
+				values = append(values, sample.Resources[resource])
+			}
+			fmt.Fprintf(&out, "%-12s %s %d\n", resource, Sparkline(values, reportSparkWidth), values[len(values)-1])
+		}
+		fmt.Fprintf(&out, "```\n")
+	}
+
+	fmt.Fprintf(&out, "\n## Workers\n\n")
+	fmt.Fprintf(&out, "| Industry | Worker | Owned | Tier | Cycles |\n|---|---|---:|---:|---:|\n")
+	for _, industry := range g.Industries {
+		for _, worker := range industry.Workers {
+			cycles := stats.Cycles[WorkerStatsKey(industry.Key, worker.Definition.Key)]
+			fmt.Fprintf(&out, "| %s | %s | %d | %d | %d |\n", industry.Name, worker.Definition.WorkerName, worker.Owned, worker.Tier, cycles)
+		}
+	}
+
+	fmt.Fprintf(&out, "\n## Milestones\n\n")
+	if len(stats.Milestones) == 0 {
+		fmt.Fprintf(&out, "None reached yet.\n")
+	}
+	for _, milestone := range stats.Milestones {
+		fmt.Fprintf(&out, "- %s reached %d after %s\n", milestone.Resource, milestone.Amount, milestone.At.Sub(stats.StartedAt).Truncate(time.Second))
+	}
+
+	fmt.Fprintf(&out, "\n## Purchase History\n\n")
+	purchases := stats.Purchases
+	if len(purchases) > reportPurchaseRow {
+		fmt.Fprintf(&out, "Showing the last %d of %d purchases.\n\n", reportPurchaseRow, len(purchases))
+		purchases = purchases[len(purchases)-reportPurchaseRow:]
+	}
+	if len(purchases) == 0 {
+		fmt.Fprintf(&out, "No purchases yet.\n")
+	} else {
+		fmt.Fprintf(&out, "| Time | Kind | Worker | Count | Cost |\n|---|---|---|---:|---|\n")
+	}
+	for _, purchase := range purchases {
+		fmt.Fprintf(&out, "| +%s | %s | %s | %d | %s |\n",
+			purchase.At.Sub(stats.StartedAt).Truncate(time.Second),
+			purchase.Kind,
+			WorkerStatsKey(purchase.Industry, purchase.Worker),
+			purchase.Count,
+			FormatCost(purchase.Cost))
+	}
+	return out.String()
+}
+
+func reportResources(g *Engine) []string {
+	seen := make(map[string]bool)
+	for resource := range g.Resources {
+		seen[resource] = true
+	}
+	for resource := range g.Stats.Earned {
+		seen[resource] = true
+	}
+	resources := make([]string, 0, len(seen))
+	for resource := range seen {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+	return resources
+}
+
+func FormatCost(cost map[string]int) string {
+	if len(cost) == 0 {
+		return "free"
+	}
+	keys := make([]string, 0, len(cost))
+	for key := range cost {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%d %s", cost[key], key))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func Sparkline(values []int, width int) string {
+	return string(AppendSparkline(nil, values, width))
+}
+
+func AppendSparkline(line []rune, values []int, width int) []rune {
+	if len(values) == 0 || width <= 0 {
+		return line
+	}
+	count := MinInt(len(values), width)
+	sample := func(index int) int {
+		return values[(index+1)*len(values)/count-1]
+	}
+	low, high := sample(0), sample(0)
+	for index := range count {
+		low = MinInt(low, sample(index))
+		high = MaxInt(high, sample(index))
+	}
+	for index := range count {
+		level := 0
+		if high > low {
+			level = (sample(index) - low) * (len(SparkLevels) - 1) / (high - low)
+		}
+		line = append(line, SparkLevels[level])
+	}
+	return line
+}
@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"math"
+	"time"
+
+	"archuser.org/go-game/config"
+)
+
+type Investment struct {
+	Worker  int
+	Upgrade bool
+	Payback time.Duration
+}
+
+func (g *Engine) ResourceValue(resource string) float64 {
+	if value, ok := g.Values[resource]; ok {
+		return value
+	}
+	return 1
+}
+
+func (g *Engine) costValue(cost map[string]int) float64 {
+	total := 0.0
+	for resource, amount := range cost {
+		total += float64(amount) * g.ResourceValue(resource)
+	}
+	return total
+}
+
+func (g *Engine) outputValue(industry IndustryState, worker config.WorkerConfig) float64 {
+	if worker.ProdRate <= 0 {
+		return 0
+	}
+	return float64(worker.ProdQuant) * g.UnitValue(industry, worker.Produces) / worker.ProdRate.Seconds()
+}
+
+func (g *Engine) UnitValue(industry IndustryState, produces string) float64 {
+	if target, ok := FindWorkerIndex(industry.Workers, produces); ok {
+		return g.costValue(industry.Workers[target].Definition.Cost)
+	}
+	return g.ResourceValue(produces)
+}
+
+func payback(cost, perSecond float64) (time.Duration, bool) {
+	if perSecond <= 0 {
+		return 0, false
+	}
+	seconds := math.Max(math.Ceil(cost/perSecond), 1)
+	if seconds > float64(math.MaxInt64/int64(time.Second)) {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+func (g *Engine) BuyPayback(industryIndex, workerIndex int) (time.Duration, bool) {
+	industry := g.Industries[industryIndex]
+	definition := industry.Workers[workerIndex].Definition
+	return payback(g.costValue(definition.Cost), g.outputValue(industry, definition))
+}
+
+func (g *Engine) UpgradePayback(industryIndex, workerIndex int) (time.Duration, bool) {
+	industry := g.Industries[industryIndex]
+	worker := industry.Workers[workerIndex]
+	definition := worker.Definition
+	if worker.Auto || definition.AutoTier <= 0 || worker.Tier+1 < definition.AutoTier {
+		return 0, false
+	}
+	return payback(g.costValue(g.UpgradeCost(industryIndex, workerIndex)), g.outputValue(industry, definition)*float64(worker.Owned))
+}
+
+func PaybackLabel(duration time.Duration, ok bool) string {
+	if !ok {
+		return tr("no return")
+	}
+	return duration.String()
+}
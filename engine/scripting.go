@@ -0,0 +1,206 @@
+package engine
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+const (
+	maxScriptSteps    = 1_000_000
+	noticeScript      = "script"
+	hookTick          = "onTick"
+	hookPurchase      = "onPurchase"
+	hookCycleComplete = "onCycleComplete"
+	hookDay           = "onDay"
+	scriptGameKey     = "game"
+	scriptStateKey    = "state"
+)
+
+type script struct {
+	name    string
+	globals starlark.StringDict
+	failed  bool
+}
+
+var scriptBuiltins = starlark.StringDict{
+	"resource":  starlark.NewBuiltin("resource", scriptResource),
+	"add":       starlark.NewBuiltin("add", scriptAdd),
+	"owned":     starlark.NewBuiltin("owned", scriptOwned),
+	"add_owned": starlark.NewBuiltin("add_owned", scriptAddOwned),
+	"set_cost":  starlark.NewBuiltin("set_cost", scriptSetCost),
+	"notify":    starlark.NewBuiltin("notify", scriptNotify),
+	"date":      starlark.NewBuiltin("date", scriptDate),
+	"random":    starlark.NewBuiltin("random", scriptRandom),
+}
+
+func scriptPredeclared() starlark.StringDict {
+	predeclared := starlark.StringDict{scriptStateKey: starlark.NewDict(0)}
+	for name, value := range scriptBuiltins {
+		predeclared[name] = value
+	}
+	return predeclared
+}
+
+func loadScripts(dir string, paths []string) ([]*script, error) {
+	scripts := make([]*script, 0, len(paths))
+	for _, path := range paths {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		thread := &starlark.Thread{Name: path}
+		thread.SetMaxExecutionSteps(maxScriptSteps)
+		globals, err := starlark.ExecFileOptions(&syntax.FileOptions{}, thread, path, nil, scriptPredeclared())
+		if err != nil {
+			return nil, fmt.Errorf("load script %s: %w", path, err)
+		}
+		scripts = append(scripts, &script{name: filepath.Base(path), globals: globals})
+	}
+	return scripts, nil
+}
+
+func (g *Engine) runHook(hook string, args ...starlark.Value) {
+	for _, current := range g.scripts {
+		function, ok := current.globals[hook].(starlark.Callable)
+		if !ok || current.failed {
+			continue
+		}
+		thread := &starlark.Thread{Name: current.name}
+		thread.SetMaxExecutionSteps(maxScriptSteps)
+		thread.SetLocal(scriptGameKey, g)
+		if _, err := starlark.Call(thread, function, args, nil); err != nil {
+			current.failed = true
+			g.Notify(noticeScript, tr("script %s disabled: %v", current.name, err))
+		}
+	}
+}
+
+func (g *Engine) subscribeScripts() {
+	if len(g.scripts) == 0 {
+		return
+	}
+	Subscribe(&g.Events, func(event Ticked) {
+		if event.Elapsed > 0 {
+			g.runHook(hookTick, starlark.Float(event.Elapsed.Seconds()))
+		}
+	})
+	Subscribe(&g.Events, func(event WorkerPurchased) {
+		g.purchaseHook(purchaseBuy, event.Industry, event.Worker, event.Count)
+	})
+	Subscribe(&g.Events, func(event TierUpgraded) {
+		g.purchaseHook(purchaseUpgrade, event.Industry, event.Worker, 1)
+	})
+	Subscribe(&g.Events, g.cycleHook)
+	Subscribe(&g.Events, func(event DayStarted) {
+		g.runHook(hookDay, starlark.MakeInt(event.Date.Year), starlark.String(event.Date.Name), starlark.MakeInt(event.Date.Day))
+	})
+}
+
+func (g *Engine) purchaseHook(kind string, industryIndex, workerIndex, count int) {
+	industry := g.Industries[industryIndex]
+	g.runHook(hookPurchase, starlark.String(kind), starlark.String(industry.Key), starlark.String(industry.Workers[workerIndex].Definition.Key), starlark.MakeInt(count))
+}
+
+func (g *Engine) cycleHook(event CycleCompleted) {
+	industry := g.Industries[event.Industry]
+	worker := industry.Workers[event.Worker]
+	g.runHook(hookCycleComplete, starlark.String(industry.Key), starlark.String(worker.Definition.Key), starlark.String(worker.Definition.Produces), starlark.MakeInt(event.Amount))
+}
+
+func scriptGame(thread *starlark.Thread) *Engine {
+	return thread.Local(scriptGameKey).(*Engine)
+}
+
+func scriptWorker(thread *starlark.Thread, industryKey, workerKey string) (*WorkerState, error) {
+	g := scriptGame(thread)
+	industryIndex, workerIndex, ok := g.FindTarget(industryKey, workerKey)
+	if !ok {
+		return nil, fmt.Errorf("unknown worker %s/%s", industryKey, workerKey)
+	}
+	return &g.Industries[industryIndex].Workers[workerIndex], nil
+}
+
+func scriptResource(thread *starlark.Thread, builtin *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var name string
+	if err := starlark.UnpackPositionalArgs(builtin.Name(), args, kwargs, 1, &name); err != nil {
+		return nil, err
+	}
+	return starlark.MakeInt(scriptGame(thread).Resources[name]), nil
+}
+
+func scriptAdd(thread *starlark.Thread, builtin *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var name string
+	var amount int
+	if err := starlark.UnpackPositionalArgs(builtin.Name(), args, kwargs, 2, &name, &amount); err != nil {
+		return nil, err
+	}
+	scriptGame(thread).changeResource(name, amount)
+	return starlark.None, nil
+}
+
+func scriptOwned(thread *starlark.Thread, builtin *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var industry, worker string
+	if err := starlark.UnpackPositionalArgs(builtin.Name(), args, kwargs, 2, &industry, &worker); err != nil {
+		return nil, err
+	}
+	target, err := scriptWorker(thread, industry, worker)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.MakeInt(target.Owned), nil
+}
+
+func scriptAddOwned(thread *starlark.Thread, builtin *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var industry, worker string
+	var count int
+	if err := starlark.UnpackPositionalArgs(builtin.Name(), args, kwargs, 3, &industry, &worker, &count); err != nil {
+		return nil, err
+	}
+	target, err := scriptWorker(thread, industry, worker)
+	if err != nil {
+		return nil, err
+	}
+	target.Owned = MaxInt(target.Owned+count, 0)
+	g := scriptGame(thread)
+	industryIndex, workerIndex, _ := g.FindTarget(industry, worker)
+	g.workerChanged(industryIndex, workerIndex)
+	return starlark.None, nil
+}
+
+func scriptSetCost(thread *starlark.Thread, builtin *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var industry, worker, resource string
+	var amount int
+	if err := starlark.UnpackPositionalArgs(builtin.Name(), args, kwargs, 4, &industry, &worker, &resource, &amount); err != nil {
+		return nil, err
+	}
+	target, err := scriptWorker(thread, industry, worker)
+	if err != nil {
+		return nil, err
+	}
+	cost := make(map[string]int, len(target.Definition.Cost))
+	for key, value := range target.Definition.Cost {
+		cost[key] = value
+	}
+	cost[resource] = MaxInt(amount, 0)
+	target.Definition.Cost = cost
+	return starlark.None, nil
+}
+
+func scriptDate(thread *starlark.Thread, builtin *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackPositionalArgs(builtin.Name(), args, kwargs, 0); err != nil {
+		return nil, err
+	}
+	date := scriptGame(thread).Date()
+	return starlark.Tuple{starlark.MakeInt(date.Year), starlark.String(date.Name), starlark.MakeInt(date.Day)}, nil
+}
+
+func scriptNotify(thread *starlark.Thread, builtin *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var message string
+	if err := starlark.UnpackPositionalArgs(builtin.Name(), args, kwargs, 1, &message); err != nil {
+		return nil, err
+	}
+	scriptGame(thread).Notify(noticeScript, message)
+	return starlark.None, nil
+}
@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+
+	"go.starlark.net/starlark"
+)
+
+type rngReader struct {
+	game *Engine
+}
+
+func (g *Engine) UseSeed(seed uint64) {
+	g.Seed = seed
+	g.pcg = rand.NewPCG(seed, seed)
+	g.rng = rand.New(g.pcg)
+}
+
+func (g *Engine) Random() float64 {
+	return g.rng.Float64()
+}
+
+func (g *Engine) rngState() []byte {
+	state, err := g.pcg.MarshalBinary()
+	if err != nil {
+		return nil
+	}
+	return state
+}
+
+func (g *Engine) restoreRNG(seed uint64, state []byte) error {
+	g.UseSeed(seed)
+	if len(state) == 0 {
+		return nil
+	}
+	if err := g.pcg.UnmarshalBinary(state); err != nil {
+		return fmt.Errorf("restore rng: %w", err)
+	}
+	return nil
+}
+
+func (r rngReader) Read(buffer []byte) (int, error) {
+	for index := range buffer {
+		buffer[index] = byte(r.game.rng.Uint32())
+	}
+	return len(buffer), nil
+}
+
+func scriptRandom(thread *starlark.Thread, builtin *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackPositionalArgs(builtin.Name(), args, kwargs, 0); err != nil {
+		return nil, err
+	}
+	return starlark.Float(scriptGame(thread).Random()), nil
+}
+
+func pluginRandom(ctx context.Context, limit int64) int64 {
+	if limit <= 0 {
+		return 0
+	}
+	return pluginContext(ctx).game.rng.Int64N(limit)
+}
@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"archuser.org/go-game/config"
+)
+
+const simulateBottlenecks = 3
+
+var simulationStart = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+type WorkerRef struct {
+	Industry int
+	Worker   int
+}
+
+type simulation struct {
+	name    string
+	game    *Engine
+	started time.Time
+	first   map[WorkerRef]time.Duration
+	bought  int
+}
+
+func Simulate(cfg config.GameConfig, names []string, duration time.Duration, seed uint64) (string, error) {
+	var out strings.Builder
+	fmt.Fprintf(&out, "# Go Game Balance Simulation\n\n")
+	fmt.Fprintf(&out, "- Simulated time: %s\n", duration)
+	fmt.Fprintf(&out, "- Seed: %d\n", seed)
+	fmt.Fprintf(&out, "- Strategies: %s\n", strings.Join(names, ", "))
+	for _, name := range names {
+		run, err := simulate(cfg, name, duration, seed)
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(run.report())
+	}
+	return out.String(), nil
+}
+
+func simulate(cfg config.GameConfig, name string, duration time.Duration, seed uint64) (*simulation, error) {
+	strategy, err := LookupStrategy(name)
+	if err != nil {
+		return nil, err
+	}
+	game, err := New(cfg, Options{Start: simulationStart, Seed: seed})
+	if err != nil {
+		return nil, fmt.Errorf("build game: %w", err)
+	}
+	run := &simulation{name: name, game: game, started: game.Now(), first: make(map[WorkerRef]time.Duration)}
+	Subscribe(&game.Events, func(event WorkerPurchased) {
+		run.bought += event.Count
+		ref := WorkerRef{Industry: event.Industry, Worker: event.Worker}
+		if _, ok := run.first[ref]; !ok {
+			run.first[ref] = event.At.Sub(run.started)
+		}
+	})
+	game.Autoplay(strategy, duration)
+	return run, nil
+}
+
+func (s *simulation) report() string {
+	var out strings.Builder
+	g := s.game
+	fmt.Fprintf(&out, "\n## Strategy: %s\n\n", s.name)
+	fmt.Fprintf(&out, "- Net worth: %d\n", g.NetWorth())
+	fmt.Fprintf(&out, "- Workers bought: %d\n", s.bought)
+
+	fmt.Fprintf(&out, "\n### Milestones\n\n")
+	if len(g.Stats.Milestones) == 0 {
+		fmt.Fprintf(&out, "None reached.\n")
+	}
+	for _, milestone := range g.Stats.Milestones {
+		fmt.Fprintf(&out, "- %s reached %d after %s\n", milestone.Resource, milestone.Amount, milestone.At.Sub(s.started).Truncate(time.Second))
+	}
+
+	fmt.Fprintf(&out, "\n### Resource Curves\n\n```\n")
+	for _, resource := range reportResources(g) {
+		values := make([]int, 0, len(g.Stats.Samples))
+		for _, sample := range g.Stats.Samples {
+			values = append(values, sample.Resources[resource])
+		}
+		fmt.Fprintf(&out, "%-12s %s %d\n", resource, Sparkline(values, reportSparkWidth), g.Resources[resource])
+	}
+	fmt.Fprintf(&out, "```\n")
+
+	fmt.Fprintf(&out, "\n### Workers\n\n")
+	fmt.Fprintf(&out, "| Industry | Worker | Owned | Tier | First bought | Busy |\n|---|---|---:|---:|---:|---:|\n")
+	for industryIndex, industry := range g.Industries {
+		for workerIndex, worker := range industry.Workers {
+			fmt.Fprintf(&out, "| %s | %s | %d | %d | %s | %s |\n", industry.Name, worker.Definition.WorkerName, worker.Owned, worker.Tier,
+				s.firstBought(industryIndex, workerIndex), s.busyShare(industry.Key, worker.Definition.Key))
+		}
+	}
+
+	fmt.Fprintf(&out, "\n### Bottlenecks\n\n")
+	for _, line := range s.bottlenecks() {
+		fmt.Fprintf(&out, "- %s\n", line)
+	}
+	return out.String()
+}
+
+func (s *simulation) firstBought(industryIndex, workerIndex int) string {
+	at, ok := s.first[WorkerRef{Industry: industryIndex, Worker: workerIndex}]
+	if !ok {
+		return "-"
+	}
+	return at.Truncate(time.Second).String()
+}
+
+func (s *simulation) busyShare(industry, worker string) string {
+	key := WorkerStatsKey(industry, worker)
+	employed := s.game.Stats.Employed[key]
+	if employed <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", 100*s.game.Stats.Busy[key].Seconds()/employed.Seconds())
+}
+
+func (s *simulation) bottlenecks() []string {
+	type slow struct {
+		label   string
+		payback time.Duration
+	}
+	var lines []string
+	var owned []slow
+	for industryIndex, industry := range s.game.Industries {
+		for workerIndex, worker := range industry.Workers {
+			label := fmt.Sprintf("%s / %s", industry.Name, worker.Definition.WorkerName)
+			if worker.Owned == 0 {
+				lines = append(lines, fmt.Sprintf("%s was never bought", label))
+				continue
+			}
+			if payback, ok := s.game.BuyPayback(industryIndex, workerIndex); ok {
+				owned = append(owned, slow{label: label, payback: payback})
+			}
+		}
+	}
+	sort.SliceStable(owned, func(i, j int) bool { return owned[i].payback > owned[j].payback })
+	for _, worker := range owned[:MinInt(len(owned), simulateBottlenecks)] {
+		lines = append(lines, fmt.Sprintf("%s pays back in %s", worker.label, worker.payback))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "none found")
+	}
+	return lines
+}
+
+func WriteSimulation(cfg config.GameConfig, names string, duration time.Duration, seed uint64, path string) error {
+	if names == "" {
+		names = "greedy"
+	}
+	report, err := Simulate(cfg, strings.Split(names, ","), duration, seed)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		_, err = os.Stdout.WriteString(report)
+		return err
+	}
+	if err := os.WriteFile(path, []byte(report), 0o644); err != nil {
+		return fmt.Errorf("write simulation: %w", err)
+	}
+	return nil
+}
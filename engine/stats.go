@@ -0,0 +1,190 @@
+package engine
+
+import (
+	"time"
+)
+
+const (
+	sampleInterval    = 30 * time.Second
+	maxSamples        = 2880
+	maxPurchases      = 1000
+	firstMilestone    = 100
+	milestoneFactor   = 10
+	purchaseBuy       = "buy"
+	purchaseUpgrade   = "upgrade"
+	statsKeySeparator = "/"
+)
+
+type Statistics struct {
+	StartedAt    time.Time                 `json:"startedAt"`
+	Earned       map[string]int            `json:"earned"`
+	Spent        map[string]int            `json:"spent"`
+	Cycles       map[string]int            `json:"cycles"`
+	Purchases    []PurchaseRecord          `json:"purchases"`
+	Milestones   []MilestoneRecord         `json:"milestones"`
+	Reached      map[string]int            `json:"reached"`
+	Samples      []ResourceSample          `json:"samples"`
+	Playtime     time.Duration             `json:"playtime"`
+	Achievements map[string]time.Time      `json:"achievements,omitempty"`
+	Output       map[string]int            `json:"output,omitempty"`
+	Busy         map[string]time.Duration  `json:"busy,omitempty"`
+	Employed     map[string]time.Duration  `json:"employed,omitempty"`
+	Invested     map[string]map[string]int `json:"invested,omitempty"`
+}
+
+type PurchaseRecord struct {
+	At       time.Time      `json:"at"`
+	Kind     string         `json:"kind"`
+	Industry string         `json:"industry"`
+	Worker   string         `json:"worker"`
+	Count    int            `json:"count"`
+	Cost     map[string]int `json:"cost"`
+}
+
+type MilestoneRecord struct {
+	At       time.Time `json:"at"`
+	Resource string    `json:"resource"`
+	Amount   int       `json:"amount"`
+}
+
+type ResourceSample struct {
+	At        time.Time      `json:"at"`
+	Resources map[string]int `json:"resources"`
+}
+
+func newStatistics(now time.Time) Statistics {
+	return Statistics{
+		StartedAt:    now,
+		Earned:       make(map[string]int),
+		Spent:        make(map[string]int),
+		Cycles:       make(map[string]int),
+		Reached:      make(map[string]int),
+		Achievements: make(map[string]time.Time),
+		Output:       make(map[string]int),
+		Busy:         make(map[string]time.Duration),
+		Employed:     make(map[string]time.Duration),
+		Invested:     make(map[string]map[string]int),
+	}
+}
+
+func WorkerStatsKey(industry, worker string) string {
+	return industry + statsKeySeparator + worker
+}
+
+func (s *Statistics) normalize(now time.Time) {
+	if s.StartedAt.IsZero() {
+		s.StartedAt = now
+	}
+	if s.Earned == nil {
+		s.Earned = make(map[string]int)
+	}
+	if s.Spent == nil {
+		s.Spent = make(map[string]int)
+	}
+	if s.Cycles == nil {
+		s.Cycles = make(map[string]int)
+	}
+	if s.Reached == nil {
+		s.Reached = make(map[string]int)
+	}
+	if s.Achievements == nil {
+		s.Achievements = make(map[string]time.Time)
+	}
+	if s.Output == nil {
+		s.Output = make(map[string]int)
+	}
+	if s.Busy == nil {
+		s.Busy = make(map[string]time.Duration)
+	}
+	if s.Employed == nil {
+		s.Employed = make(map[string]time.Duration)
+	}
+	if s.Invested == nil {
+		s.Invested = make(map[string]map[string]int)
+	}
+}
+
+func (s *Statistics) recordEarned(resource string, amount int) {
+	s.Earned[resource] += amount
+}
+
+func (s *Statistics) recordCycle(industry, worker string, produced int) {
+	key := WorkerStatsKey(industry, worker)
+	s.Cycles[key]++
+	s.Output[key] += produced
+}
+
+func (s *Statistics) recordWork(industry, worker string, elapsed time.Duration, running bool) {
+	key := WorkerStatsKey(industry, worker)
+	s.Employed[key] += elapsed
+	if running {
+		s.Busy[key] += elapsed
+	}
+}
+
+func (s *Statistics) recordPurchase(record PurchaseRecord) {
+	invested := s.Invested[record.Industry]
+	if invested == nil {
+		invested = make(map[string]int)
+		s.Invested[record.Industry] = invested
+	}
+	for resource, amount := range record.Cost {
+		s.Spent[resource] += amount
+		invested[resource] += amount
+	}
+	s.Purchases = append(s.Purchases, record)
+	if len(s.Purchases) > maxPurchases {
+		s.Purchases = append([]PurchaseRecord(nil), s.Purchases[len(s.Purchases)-maxPurchases:]...)
+	}
+}
+
+func (s *Statistics) unrecordPurchase(kind, industry, worker string, cost map[string]int) {
+	for resource, amount := range cost {
+		s.Spent[resource] -= amount
+		s.Invested[industry][resource] -= amount
+	}
+	for index := len(s.Purchases) - 1; index >= 0; index-- {
+		record := s.Purchases[index]
+		if record.Kind == kind && record.Industry == industry && record.Worker == worker {
+			s.Purchases = append(s.Purchases[:index], s.Purchases[index+1:]...)
+			return
+		}
+	}
+}
+
+func (s *Statistics) observe(now time.Time, resources map[string]int) []MilestoneRecord {
+	var reached []MilestoneRecord
+	for resource, amount := range resources {
+		threshold := MaxInt(s.Reached[resource]*milestoneFactor, firstMilestone)
+		for amount >= threshold {
+			s.Reached[resource] = threshold
+			reached = append(reached, MilestoneRecord{At: now, Resource: resource, Amount: threshold})
+			threshold *= milestoneFactor
+		}
+	}
+	s.Milestones = append(s.Milestones, reached...)
+	if len(s.Samples) > 0 && now.Sub(s.Samples[len(s.Samples)-1].At) < sampleInterval {
+		return reached
+	}
+	s.Samples = append(s.Samples, ResourceSample{At: now, Resources: CopyResources(resources)})
+	if len(s.Samples) > maxSamples {
+		s.Samples = thinSamples(s.Samples)
+	}
+	return reached
+}
+
+func thinSamples(samples []ResourceSample) []ResourceSample {
+	thinned := make([]ResourceSample, 0, len(samples)/2+1)
+	for index := 0; index < len(samples); index += 2 {
+		thinned = append(thinned, samples[index])
+	}
+	return thinned
+}
+
+func CopyResources(resources map[string]int) map[string]int {
+	copied := make(map[string]int, len(resources))
+	for key, value := range resources {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,40 @@
+package engine
+
+import (
+	"time"
+)
+
+const DefaultStatusTimeout = 5 * time.Second
+
+type StatusClass int
+
+const (
+	statusInfo StatusClass = iota
+	StatusSuccess
+	StatusError
+)
+
+type Status struct {
+	Message string
+	Class   StatusClass
+}
+
+func InfoStatus(message string) Status {
+	return Status{Message: message, Class: statusInfo}
+}
+
+func SuccessStatus(message string) Status {
+	return Status{Message: message, Class: StatusSuccess}
+}
+
+func ErrorStatus(message string) Status {
+	return Status{Message: message, Class: StatusError}
+}
+
+func (s Status) Failed() bool {
+	return s.Class == StatusError
+}
+
+func (s Status) String() string {
+	return s.Message
+}
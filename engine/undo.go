@@ -0,0 +1,118 @@
+package engine
+
+import (
+	"time"
+)
+
+const (
+	undoWindow = 30 * time.Second
+	maxUndo    = 50
+	replayUndo = "undo"
+)
+
+type undoEntry struct {
+	At       time.Time
+	Kind     string
+	Industry int
+	Worker   int
+	Count    int
+	Cost     map[string]int
+	Unlocked bool
+}
+
+type undoHistory struct {
+	done    []undoEntry
+	undone  []undoEntry
+	redoing bool
+	pushed  int
+}
+
+func (g *Engine) subscribeUndo() {
+	Subscribe(&g.Events, func(event WorkerPurchased) {
+		g.pushUndo(undoEntry{At: event.At, Kind: purchaseBuy, Industry: event.Industry, Worker: event.Worker, Count: event.Count, Cost: event.Cost})
+	})
+	Subscribe(&g.Events, func(event TierUpgraded) {
+		worker := g.Industries[event.Industry].Workers[event.Worker]
+		unlocked := worker.Definition.AutoTier > 0 && event.Tier == worker.Definition.AutoTier
+		g.pushUndo(undoEntry{At: event.At, Kind: purchaseUpgrade, Industry: event.Industry, Worker: event.Worker, Count: 1, Cost: event.Cost, Unlocked: unlocked})
+	})
+}
+
+func (g *Engine) pushUndo(entry undoEntry) {
+	g.undo.pushed++
+	g.undo.done = append(g.undo.done, entry)
+	if len(g.undo.done) > maxUndo {
+		g.undo.done = g.undo.done[len(g.undo.done)-maxUndo:]
+	}
+	if !g.undo.redoing {
+		g.undo.undone = nil
+	}
+}
+
+func (g *Engine) Undo() Status {
+	g.record(ReplayEvent{Kind: replayUndo}, g.Now())
+	if g.Remote != nil {
+		return ErrorStatus(tr("undo is not available in co-op"))
+	}
+	if len(g.undo.done) == 0 {
+		return ErrorStatus(tr("nothing to undo"))
+	}
+	entry := g.undo.done[len(g.undo.done)-1]
+	if g.Now().Sub(entry.At) > undoWindow {
+		g.undo.done = nil
+		return ErrorStatus(tr("nothing to undo"))
+	}
+	worker := &g.Industries[entry.Industry].Workers[entry.Worker]
+	if entry.Kind == purchaseBuy && worker.Owned < entry.Count {
+		return ErrorStatus(tr("cannot undo: %s are no longer owned", worker.Definition.WorkerName))
+	}
+	g.undo.done = g.undo.done[:len(g.undo.done)-1]
+	g.undo.undone = append(g.undo.undone, entry)
+	g.refund(entry)
+	g.revision++
+	if entry.Kind == purchaseBuy {
+		worker.Owned -= entry.Count
+		g.workerChanged(entry.Industry, entry.Worker)
+		return SuccessStatus(tr("undid buying %s %s", FormatNumber(entry.Count, false), worker.Definition.WorkerName))
+	}
+	worker.Tier--
+	if entry.Unlocked {
+		worker.Auto = false
+	}
+	g.workerChanged(entry.Industry, entry.Worker)
+	return SuccessStatus(tr("undid upgrade: %s back to tier %d", worker.Definition.WorkerName, worker.Tier))
+}
+
+func (g *Engine) Redo() Status {
+	if g.Remote != nil {
+		return ErrorStatus(tr("undo is not available in co-op"))
+	}
+	if len(g.undo.undone) == 0 {
+		return ErrorStatus(tr("nothing to redo"))
+	}
+	entry := g.undo.undone[len(g.undo.undone)-1]
+	pushed := g.undo.pushed
+	g.undo.redoing = true
+	defer func() { g.undo.redoing = false }()
+	var status Status
+	if entry.Kind == purchaseBuy {
+		status = g.BuyCount(entry.Industry, entry.Worker, entry.Count)
+	} else {
+		status = g.UpgradeWorker(entry.Industry, entry.Worker)
+	}
+	if g.undo.pushed != pushed {
+		g.undo.undone = g.undo.undone[:len(g.undo.undone)-1]
+	}
+	return status
+}
+
+func (g *Engine) refund(entry undoEntry) {
+	if entry.Cost == nil {
+		return
+	}
+	for resource, amount := range entry.Cost {
+		g.Resources[resource] += amount
+	}
+	industry := g.Industries[entry.Industry]
+	g.Stats.unrecordPurchase(entry.Kind, industry.Key, industry.Workers[entry.Worker].Definition.Key, entry.Cost)
+}
@@ -0,0 +1,43 @@
+package locale
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	Dir     = "locales"
+	Default = "en"
+)
+
+var translations map[string]string
+
+func Load(dir, lang string) error {
+	if lang == "" || lang == Default {
+		translations = nil
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, lang+".yml"))
+	if err != nil {
+		return fmt.Errorf("read locale: %w", err)
+	}
+	loaded := make(map[string]string)
+	if err := yaml.Unmarshal(data, &loaded); err != nil {
+		return fmt.Errorf("parse locale: %w", err)
+	}
+	translations = loaded
+	return nil
+}
+
+func Tr(text string, args ...any) string {
+	if translated, ok := translations[text]; ok && translated != "" {
+		text = translated
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
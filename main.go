@@ -1,36 +1,349 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"archuser.org/go-game/config"
+	"archuser.org/go-game/engine"
+	"archuser.org/go-game/locale"
+	"archuser.org/go-game/save"
+	"archuser.org/go-game/tui"
 )
 
+const defaultConfigPath = "config/game.yml"
+
 func main() {
-	configPath := flag.String("config", "config/game.yml", "path to game configuration")
-	devMode := flag.Bool("dev", false, "enable developer mode (free money mode)")
-	flag.Parse()
+	if err := runCommand(os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func runPlay(args []string) error {
+	fs := commandFlags("play", "")
+	configPath := fs.String("config", defaultConfigPath, "path to game configuration")
+	devMode := fs.Bool("dev", false, "enable developer mode (free money mode)")
+	profileName := fs.String("profile", "", "profile name (stored under profiles/)")
+	hardcore := fs.Bool("hardcore", false, "create the profile in hardcore mode")
+	autosave := fs.Duration("autosave", 0, "autosave interval (0 disables)")
+	exportPath := fs.String("export-bundle", "", "export config, its scripts and plugins, and save as a bundle and exit")
+	importPath := fs.String("import-bundle", "", "import a bundle as a new profile (requires -profile)")
+	reportPath := fs.String("report", "", "write a Markdown run report here when the session ends")
+	settingsPath := fs.String("settings", "", "path to the settings file (default: inside the profile)")
+	fps := fs.Int("fps", 0, "screen refresh rate in frames per second (default from settings, else 10)")
+	tickRate := fs.Duration("tick", 0, "simulation tick interval, independent of the frame rate (default 50ms)")
+	noMenu := fs.Bool("no-menu", false, "skip the main menu and start playing immediately")
+	plain := fs.Bool("plain", false, "screen-reader friendly plain text mode (line commands on stdin)")
+	headless := fs.Bool("headless", false, "run without a terminal UI: console commands on stdin, events on stdout, save on SIGINT/SIGTERM")
+	logEvents := fs.String("log-events", "", "append status messages and game events to this file")
+	lang := fs.String("lang", locale.Default, "interface language (loads locales/<lang>.yml)")
+	recordPath := fs.String("record", "", "record inputs and tick timings to this replay file")
+	replayPath := fs.String("replay", "", "play back a replay file recorded with -record (read-only)")
+	apiAddr := fs.String("api", "", "serve the HTTP JSON API and web dashboard on this address (e.g. 127.0.0.1:8077, open /?token=...)")
+	apiToken := fs.String("api-token", os.Getenv(tui.APITokenEnv), "bearer token required by the HTTP API (default from "+tui.APITokenEnv+")")
+	serveAddr := fs.String("serve", "", "host a co-op server on this TCP address (runs headless)")
+	connectAddr := fs.String("connect", "", "join the co-op server at this TCP address")
+	coopToken := fs.String("coop-token", os.Getenv(tui.CoopTokenEnv), "token co-op players must present to -serve, and that -connect sends (default from "+tui.CoopTokenEnv+")")
+	playerName := fs.String("name", os.Getenv("USER"), "player name shown to other co-op players")
+	logPath := fs.String("log", "", "write structured logs of engine decisions, errors and performance to this file")
+	logFormat := fs.String("log-format", engine.LogFormatJSON, "structured log format: json or logfmt")
+	logLevel := fs.String("log-level", "info", "minimum structured log level: debug, info, warn or error")
+	metricsAddr := fs.String("metrics", "", "serve Prometheus metrics at /metrics and expvar at /debug/vars on this address")
+	botName := fs.String("bot", "", "let a built-in strategy play (greedy, cheapest or planner)")
+	sshAddr := fs.String("ssh", "", "serve the TUI over SSH on this address; each login plays the profile named after the SSH user")
+	sshKey := fs.String("ssh-host-key", tui.DefaultSSHHostKey, "SSH host key file (generated on first use)")
+	sshAuthorized := fs.String("ssh-authorized-keys", tui.DefaultAuthorizedKeys(), "public keys allowed to log in over SSH")
+	ghostPath := fs.String("ghost", "", "race the milestone times recorded in this save file")
+	overlayPath := fs.String("overlay", "", "keep headline stats in this file for stream overlays (JSON if it ends in .json, else text)")
+	raceHost := fs.String("race-host", "", "wait for one opponent on this TCP address and race to -race-target")
+	raceJoin := fs.String("race-join", "", "race the player waiting at this TCP address")
+	raceGoal := fs.String("race-target", tui.DefaultRaceGoal, "race goal as resource:amount (lifetime earned) or networth:amount")
+	saveFormat := fs.String("save-format", save.FormatJSON, "encoding for new saves: "+strings.Join(save.Formats(), ", ")+" (any format loads)")
+	seed := fs.Uint64("seed", 0, "seed the engine's random number generator so runs can be reproduced (default random; saves keep their own)")
+	pprofAddr := fs.String("pprof", "", "serve net/http/pprof on this address (e.g. :6060, bound to localhost unless a host is given)")
+	cpuProfilePath := fs.String("cpuprofile", "", "write a CPU profile of the session to this file")
+	memProfilePath := fs.String("memprofile", "", "write a heap profile to this file when the session ends")
+	controlPath := fs.String("control", "", "accept line commands (status, save, buy, pause, help) on this Unix socket")
+	noColor := fs.Bool("no-color", false, "monochrome UI using bold/reverse for emphasis (also honors NO_COLOR)")
+	fs.Parse(args)
+
+	if *pprofAddr != "" {
+		server, err := StartPprof(*pprofAddr)
+		if err != nil {
+			return fmt.Errorf("start pprof: %w", err)
+		}
+		defer server.Close()
+	}
+	if *cpuProfilePath != "" {
+		profile, err := StartCPUProfile(*cpuProfilePath)
+		if err != nil {
+			return fmt.Errorf("start cpu profile: %w", err)
+		}
+		defer profile.Close()
+	}
 
-	cfg, err := LoadConfig(*configPath)
+	if err := locale.Load(locale.Dir, *lang); err != nil {
+		return fmt.Errorf("load locale: %w", err)
+	}
+
+	if *sshAddr != "" {
+		options := tui.SSHOptions{HostKey: *sshKey, AuthorizedKeys: *sshAuthorized, ConfigPath: *configPath, AutosaveEvery: *autosave, SaveFormat: *saveFormat}
+		if err := tui.ServeSSH(*sshAddr, options); err != nil {
+			return fmt.Errorf("host over ssh: %w", err)
+		}
+		return nil
+	}
+
+	mode := save.ProfileNormal
+	if *hardcore {
+		mode = save.ProfileHardcore
+	}
+	var profile save.Profile
+	var err error
+	if *importPath != "" {
+		profile, err = save.ImportBundle(*importPath, *profileName)
+		if err != nil {
+			return fmt.Errorf("import bundle: %w", err)
+		}
+	} else {
+		profile, err = save.OpenProfile(*profileName, mode)
+		if err != nil {
+			return fmt.Errorf("open profile: %w", err)
+		}
+	}
+	if profile.Ended {
+		return fmt.Errorf("profile %s has ended (%s)", profile.Name, profile.EndReason)
+	}
+	if profile.Hardcore() && *devMode {
+		return errors.New("developer mode is not available for hardcore profiles")
+	}
+
+	if !flagSet(fs, "config") {
+		if _, err := os.Stat(profile.ConfigPath()); err == nil {
+			*configPath = profile.ConfigPath()
+		}
+	}
+	if *settingsPath == "" {
+		*settingsPath = profile.SettingsPath()
+	}
+	if *exportPath != "" {
+		if err := save.ExportBundle(*exportPath, *configPath, *settingsPath, profile); err != nil {
+			return fmt.Errorf("export bundle: %w", err)
+		}
+		return nil
+	}
+
+	cfg, err := config.LoadConfig(*configPath)
 	if err != nil {
-		log.Fatalf("failed to load config: %v", err)
+		return fmt.Errorf("load config: %w", err)
 	}
 
-	game, err := BuildGame(cfg)
+	options := engine.RealTime()
+	if flagSet(fs, "seed") {
+		options.Seed = *seed
+	}
+	game, err := engine.New(cfg, options)
 	if err != nil {
-		log.Fatalf("failed to build game: %v", err)
+		return fmt.Errorf("build game: %w", err)
 	}
 	game.DevMode = *devMode
+	if err := save.UseFormat(game, *saveFormat); err != nil {
+		return fmt.Errorf("select save format: %w", err)
+	}
+	if *ghostPath != "" {
+		ghost, err := save.LoadGhost(*ghostPath)
+		if err != nil {
+			return fmt.Errorf("load ghost: %w", err)
+		}
+		game.UseGhost(ghost)
+	}
+	var bot engine.Strategy
+	if *botName != "" {
+		if bot, err = engine.LookupStrategy(*botName); err != nil {
+			return fmt.Errorf("start bot: %w", err)
+		}
+	}
+	if profile.Hardcore() || *importPath != "" {
+		if err := save.Resume(game, profile); err != nil {
+			return fmt.Errorf("resume save: %w", err)
+		}
+	}
+
+	if *replayPath != "" {
+		replay, err := engine.LoadReplay(*replayPath)
+		if err != nil {
+			return fmt.Errorf("load replay: %w", err)
+		}
+		if err := game.StartPlayback(replay, time.Now()); err != nil {
+			return fmt.Errorf("start replay: %w", err)
+		}
+	} else if *recordPath != "" {
+		if err := game.StartRecording(); err != nil {
+			return fmt.Errorf("start recording: %w", err)
+		}
+	}
+
+	settings, err := tui.LoadSettings(*settingsPath)
+	if err != nil {
+		return fmt.Errorf("load settings: %w", err)
+	}
+
+	var eventLog *os.File
+	if *logEvents != "" {
+		eventLog, err = tui.OpenEventLog(*logEvents)
+		if err != nil {
+			return fmt.Errorf("open event log: %w", err)
+		}
+		defer eventLog.Close()
+	}
 
-	ui, err := NewUI(game)
+	if *logPath != "" {
+		logger, closer, err := engine.OpenLogger(*logPath, *logFormat, *logLevel)
+		if err != nil {
+			return fmt.Errorf("open log: %w", err)
+		}
+		defer closer.Close()
+		game.UseLogger(logger)
+		logger.Info("session started", "build", engine.CurrentBuild().String(), "profile", profile.Name, "config", *configPath, "headless", *headless || *serveAddr != "", "plain", *plain)
+		defer logger.Info("session ended")
+	}
+
+	if *connectAddr != "" && (*plain || *headless || *serveAddr != "") {
+		return errors.New("-connect needs the terminal UI and cannot be combined with -plain, -headless or -serve")
+	}
+	if *serveAddr != "" {
+		*headless = true
+	}
+	fallback := !*plain && !*headless && !InteractiveTerminal()
+	if fallback {
+		fmt.Fprintln(os.Stderr, "no interactive terminal, falling back to plain mode")
+		*plain = true
+	}
+	newUI := tui.NewUI
+	if *plain || *headless {
+		newUI = tui.NewPlainUI
+	}
+	ui, err := newUI(game, profile, settings)
+	if err != nil && !*plain && !*headless {
+		fmt.Fprintf(os.Stderr, "failed to initialize terminal UI (%v), falling back to plain mode\n", err)
+		*plain, fallback = true, true
+		ui, err = tui.NewPlainUI(game, profile, settings)
+	}
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to initialize UI: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("initialize UI: %w", err)
+	}
+	if *autosave > 0 && (ui.AutosaveEvery == 0 || *autosave < ui.AutosaveEvery) {
+		ui.AutosaveEvery = *autosave
+	}
+	if *fps > 0 {
+		ui.FPS = *fps
+	}
+	ui.TickRate = *tickRate
+	ui.Bot = bot
+	ui.Overlay = *overlayPath
+	ui.KeepAlive = fallback
+	ui.Seed, ui.SeedSet = *seed, flagSet(fs, "seed")
+	if eventLog != nil {
+		ui.EventLog = eventLog
+	}
+	if *noColor || tui.NoColorRequested() {
+		ui.UseMonochrome()
+	}
+	ui.ConfigPath = *configPath
+	if *apiAddr != "" {
+		server, err := ui.StartAPI(*apiAddr, *apiToken)
+		if err != nil {
+			ui.Close()
+			return fmt.Errorf("start api: %w", err)
+		}
+		defer server.Close()
+	}
+	if *controlPath != "" {
+		listener, err := ui.StartControl(*controlPath)
+		if err != nil {
+			ui.Close()
+			return fmt.Errorf("start control socket: %w", err)
+		}
+		defer listener.Close()
+	}
+	if *metricsAddr != "" {
+		server, err := ui.StartMetrics(*metricsAddr)
+		if err != nil {
+			ui.Close()
+			return fmt.Errorf("start metrics: %w", err)
+		}
+		defer server.Close()
+	}
+	if *serveAddr != "" {
+		server, err := ui.StartCoop(*serveAddr, *coopToken)
+		if err != nil {
+			ui.Close()
+			return fmt.Errorf("start co-op server: %w", err)
+		}
+		defer server.Close()
+	}
+	if *connectAddr != "" {
+		if err := ui.JoinCoop(*connectAddr, *playerName, *coopToken); err != nil {
+			ui.Close()
+			return fmt.Errorf("join co-op server: %w", err)
+		}
+	}
+	if *raceHost != "" || *raceJoin != "" {
+		closer, err := tui.StartRace(ui, *raceHost, *raceJoin, *playerName, *raceGoal)
+		if err != nil {
+			ui.Close()
+			return fmt.Errorf("start race: %w", err)
+		}
+		defer closer.Close()
+	}
+	if !*plain && !*headless && !*noMenu && *replayPath == "" && *connectAddr == "" {
+		ui.OpenMenu()
 	}
 
-	if err := ui.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "UI error: %v\n", err)
-		os.Exit(1)
+	sessionStart := time.Now()
+	run := ui.Run
+	if *plain {
+		run = func() error { return ui.RunPlain(os.Stdin, os.Stdout) }
 	}
+	if *headless {
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+		run = func() error { return ui.RunHeadless(os.Stdin, os.Stdout, stop) }
+	}
+	if err := ui.Guard(os.Stderr, run); err != nil {
+		return fmt.Errorf("run UI: %w", err)
+	}
+	if *recordPath != "" {
+		if err := game.SaveReplay(*recordPath); err != nil {
+			return fmt.Errorf("write replay: %w", err)
+		}
+	}
+	if *memProfilePath != "" {
+		if err := WriteMemProfile(*memProfilePath); err != nil {
+			return fmt.Errorf("write memory profile: %w", err)
+		}
+	}
+	if *reportPath != "" {
+		if err := engine.WriteReport(*reportPath, game, sessionStart, time.Now()); err != nil {
+			return fmt.Errorf("write report: %w", err)
+		}
+	}
+	return nil
+}
+
+func flagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
 }
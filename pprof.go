@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"runtime"
+	runtimepprof "runtime/pprof"
+)
+
+const pprofHost = "127.0.0.1"
+
+type cpuProfile struct {
+	file *os.File
+}
+
+func StartPprof(addr string) (*http.Server, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("parse pprof address: %w", err)
+	}
+	if host == "" {
+		host = pprofHost
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return nil, fmt.Errorf("listen pprof: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	return server, nil
+}
+
+func StartCPUProfile(path string) (io.Closer, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("create cpu profile: %w", err)
+	}
+	if err := runtimepprof.StartCPUProfile(file); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("start cpu profile: %w", err)
+	}
+	return cpuProfile{file: file}, nil
+}
+
+func (p cpuProfile) Close() error {
+	runtimepprof.StopCPUProfile()
+	return p.file.Close()
+}
+
+func WriteMemProfile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create memory profile: %w", err)
+	}
+	defer file.Close()
+	runtime.GC()
+	if err := runtimepprof.WriteHeapProfile(file); err != nil {
+		return fmt.Errorf("write memory profile: %w", err)
+	}
+	return nil
+}
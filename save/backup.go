@@ -0,0 +1,104 @@
+package save
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+const (
+	BackupDir   = "backups"
+	maxBackups  = 20
+	backupStamp = "20060102-150405.000000"
+)
+
+func backupExisting(path string, now time.Time) error {
+	payload, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read existing save: %w", err)
+	}
+	target, err := prepareBackup(path, now)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(target, payload, 0o644); err != nil {
+		return fmt.Errorf("write backup: %w", err)
+	}
+	return pruneBackups(path)
+}
+
+func backupLive(g *engine.Engine, path string, now time.Time) error {
+	target, err := prepareBackup(path, now)
+	if err != nil {
+		return err
+	}
+	if err := Autosave(g, target); err != nil {
+		return fmt.Errorf("backup live state: %w", err)
+	}
+	return pruneBackups(path)
+}
+
+func prepareBackup(path string, now time.Time) (string, error) {
+	dir := filepath.Join(filepath.Dir(path), BackupDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create backup dir: %w", err)
+	}
+	prefix, ext := backupName(path)
+	return filepath.Join(dir, fmt.Sprintf("%s%s%s", prefix, now.Format(backupStamp), ext)), nil
+}
+
+func backupName(path string) (string, string) {
+	base := filepath.Base(path)
+	ext := filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "-", ext
+}
+
+func BackupNames(path string) ([]string, error) {
+	dir := filepath.Join(filepath.Dir(path), BackupDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("list backups: %w", err)
+	}
+	prefix, ext := backupName(path)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func BackupTime(path, name string) (time.Time, error) {
+	prefix, ext := backupName(path)
+	return time.Parse(backupStamp, strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext))
+}
+
+func pruneBackups(path string) error {
+	names, err := BackupNames(path)
+	if err != nil {
+		return err
+	}
+	if len(names) <= maxBackups {
+		return nil
+	}
+	dir := filepath.Join(filepath.Dir(path), BackupDir)
+	for _, name := range names[:len(names)-maxBackups] {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("prune backup: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package save
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"archuser.org/go-game/config"
+)
+
+const (
+	bundleVersion  = 1
+	bundleManifest = "manifest.json"
+	bundleConfig   = "config.yml"
+	bundleSave     = "savegame.json"
+	bundleSettings = "settings.yml"
+	bundleFiles    = "files/"
+	maxBundleEntry = 16 << 20
+)
+
+type bundleInfo struct {
+	Version   int         `json:"version"`
+	Profile   string      `json:"profile,omitempty"`
+	Mode      ProfileMode `json:"mode"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+func ExportBundle(path, configPath, settingsPath string, profile Profile) error {
+	entries := make(map[string][]byte)
+	configData, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	entries[bundleConfig] = configData
+	cfg, err := config.ParseConfig(configData)
+	if err != nil {
+		return fmt.Errorf("parse config: %w", err)
+	}
+	for _, ref := range bundleRefs(cfg) {
+		if !filepath.IsLocal(ref) {
+			return fmt.Errorf("cannot bundle %s: referenced files must live under the config directory", ref)
+		}
+		payload, err := os.ReadFile(filepath.Join(filepath.Dir(configPath), ref))
+		if err != nil {
+			return fmt.Errorf("read %s: %w", ref, err)
+		}
+		entries[bundleEntry(ref)] = payload
+	}
+
+	optional := map[string]string{bundleSave: profile.SavePath(), bundleSettings: settingsPath}
+	for name, source := range optional {
+		payload, err := os.ReadFile(source)
+		if err == nil {
+			entries[name] = payload
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("read %s: %w", name, err)
+		}
+	}
+
+	manifest, err := json.MarshalIndent(bundleInfo{
+		Version:   bundleVersion,
+		Profile:   profile.Name,
+		Mode:      profile.Mode,
+		CreatedAt: time.Now(),
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serialize manifest: %w", err)
+	}
+	entries[bundleManifest] = manifest
+
+	return writeBundle(path, entries)
+}
+
+func bundleRefs(cfg config.GameConfig) []string {
+	return slices.Concat(cfg.Scripts, cfg.Plugins)
+}
+
+func bundleEntry(ref string) string {
+	return bundleFiles + filepath.ToSlash(filepath.Clean(ref))
+}
+
+func bundleOrder(entries map[string][]byte) []string {
+	names := []string{bundleManifest, bundleConfig, bundleSave, bundleSettings}
+	files := make([]string, 0, len(entries))
+	for name := range entries {
+		if strings.HasPrefix(name, bundleFiles) {
+			files = append(files, name)
+		}
+	}
+	slices.Sort(files)
+	return append(names, files...)
+}
+
+func writeBundle(path string, entries map[string][]byte) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create bundle: %w", err)
+	}
+	defer file.Close()
+
+	compressed := gzip.NewWriter(file)
+	archive := tar.NewWriter(compressed)
+	for _, name := range bundleOrder(entries) {
+		payload, ok := entries[name]
+		if !ok {
+			continue
+		}
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0o644,
+			Size:    int64(len(payload)),
+			ModTime: time.Now(),
+		}
+		if err := archive.WriteHeader(header); err != nil {
+			return fmt.Errorf("write bundle entry %s: %w", name, err)
+		}
+		if _, err := archive.Write(payload); err != nil {
+			return fmt.Errorf("write bundle entry %s: %w", name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("finish bundle: %w", err)
+	}
+	if err := compressed.Close(); err != nil {
+		return fmt.Errorf("finish bundle: %w", err)
+	}
+	return file.Close()
+}
+
+func ImportBundle(path, name string) (Profile, error) {
+	if name == "" {
+		return Profile{}, fmt.Errorf("import requires a profile name")
+	}
+	if _, err := loadProfile(name); err == nil {
+		return Profile{}, fmt.Errorf("profile %s already exists", name)
+	}
+
+	entries, err := readBundle(path)
+	if err != nil {
+		return Profile{}, err
+	}
+	var manifest bundleInfo
+	if err := json.Unmarshal(entries[bundleManifest], &manifest); err != nil {
+		return Profile{}, fmt.Errorf("parse manifest: %w", err)
+	}
+	if manifest.Version > bundleVersion {
+		return Profile{}, fmt.Errorf("unsupported bundle version %d", manifest.Version)
+	}
+	configData, ok := entries[bundleConfig]
+	if !ok {
+		return Profile{}, fmt.Errorf("bundle missing %s", bundleConfig)
+	}
+	cfg, err := config.ParseConfig(configData)
+	if err != nil {
+		return Profile{}, fmt.Errorf("bundle config: %w", err)
+	}
+	for _, ref := range bundleRefs(cfg) {
+		if _, ok := entries[bundleEntry(ref)]; !ok || !filepath.IsLocal(ref) {
+			return Profile{}, fmt.Errorf("bundle missing %s referenced by its config", ref)
+		}
+	}
+
+	profile := Profile{Name: name, Mode: ProfileNormal, CreatedAt: time.Now()}
+	if err := profile.Save(); err != nil {
+		return Profile{}, err
+	}
+	if err := os.WriteFile(profile.ConfigPath(), configData, 0o644); err != nil {
+		return Profile{}, fmt.Errorf("write config: %w", err)
+	}
+	if save, ok := entries[bundleSave]; ok {
+		if err := os.WriteFile(profile.SavePath(), save, 0o644); err != nil {
+			return Profile{}, fmt.Errorf("write save: %w", err)
+		}
+	}
+	if settings, ok := entries[bundleSettings]; ok {
+		if err := os.WriteFile(profile.SettingsPath(), settings, 0o644); err != nil {
+			return Profile{}, fmt.Errorf("write settings: %w", err)
+		}
+	}
+	for _, ref := range bundleRefs(cfg) {
+		target := filepath.Join(profile.Dir(), ref)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return Profile{}, fmt.Errorf("write %s: %w", ref, err)
+		}
+		if err := os.WriteFile(target, entries[bundleEntry(ref)], 0o644); err != nil {
+			return Profile{}, fmt.Errorf("write %s: %w", ref, err)
+		}
+	}
+	return profile, nil
+}
+
+func readBundle(path string) (map[string][]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open bundle: %w", err)
+	}
+	defer file.Close()
+
+	compressed, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("read bundle: %w", err)
+	}
+	defer compressed.Close()
+
+	entries := make(map[string][]byte)
+	archive := tar.NewReader(compressed)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read bundle: %w", err)
+		}
+		name := filepath.ToSlash(filepath.Clean(header.Name))
+		if !strings.HasPrefix(name, bundleFiles) || !filepath.IsLocal(name) {
+			name = filepath.Base(name)
+		}
+		if header.Typeflag != tar.TypeReg || header.Size > maxBundleEntry {
+			continue
+		}
+		payload, err := io.ReadAll(io.LimitReader(archive, maxBundleEntry))
+		if err != nil {
+			return nil, fmt.Errorf("read bundle entry %s: %w", name, err)
+		}
+		entries[name] = payload
+	}
+	if _, ok := entries[bundleManifest]; !ok {
+		return nil, fmt.Errorf("bundle missing %s", bundleManifest)
+	}
+	return entries, nil
+}
@@ -0,0 +1,107 @@
+package save
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"archuser.org/go-game/engine"
+)
+
+const (
+	FormatJSON        = "json"
+	saveFormatGob     = "gob"
+	saveFormatMsgpack = "msgpack"
+	saveMagic         = "go-game-save "
+)
+
+type SaveCodec interface {
+	Marshal(snapshot engine.SaveGame) ([]byte, error)
+	Unmarshal(payload []byte, snapshot *engine.SaveGame) error
+}
+
+var saveCodecs = map[string]SaveCodec{
+	FormatJSON:        jsonCodec{},
+	saveFormatGob:     gobCodec{},
+	saveFormatMsgpack: msgpackCodec{},
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(snapshot engine.SaveGame) ([]byte, error) {
+	return json.MarshalIndent(snapshot, "", "  ")
+}
+
+func (jsonCodec) Unmarshal(payload []byte, snapshot *engine.SaveGame) error {
+	return json.Unmarshal(payload, snapshot)
+}
+
+type gobCodec struct{}
+
+func (gobCodec) Marshal(snapshot engine.SaveGame) ([]byte, error) {
+	var buffer bytes.Buffer
+	err := gob.NewEncoder(&buffer).Encode(snapshot)
+	return buffer.Bytes(), err
+}
+
+func (gobCodec) Unmarshal(payload []byte, snapshot *engine.SaveGame) error {
+	return gob.NewDecoder(bytes.NewReader(payload)).Decode(snapshot)
+}
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) Marshal(snapshot engine.SaveGame) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := msgpack.NewEncoder(&buffer)
+	encoder.SetCustomStructTag("json")
+	err := encoder.Encode(snapshot)
+	return buffer.Bytes(), err
+}
+
+func (msgpackCodec) Unmarshal(payload []byte, snapshot *engine.SaveGame) error {
+	decoder := msgpack.NewDecoder(bytes.NewReader(payload))
+	decoder.SetCustomStructTag("json")
+	return decoder.Decode(snapshot)
+}
+
+func Formats() []string {
+	return engine.SortedKeys(saveCodecs)
+}
+
+func UseFormat(g *engine.Engine, format string) error {
+	if _, ok := saveCodecs[format]; !ok {
+		return fmt.Errorf("unknown save format %q (%s)", format, strings.Join(Formats(), ", "))
+	}
+	g.SaveFormat = format
+	return nil
+}
+
+func encodeSave(format string, snapshot engine.SaveGame) ([]byte, error) {
+	if format == "" {
+		format = FormatJSON
+	}
+	payload, err := saveCodecs[format].Marshal(snapshot)
+	if err != nil || format == FormatJSON {
+		return payload, err
+	}
+	return append([]byte(saveMagic+format+"\n"), payload...), nil
+}
+
+func decodeSave(payload []byte) (engine.SaveGame, error) {
+	format, body := FormatJSON, payload
+	if rest, ok := bytes.CutPrefix(payload, []byte(saveMagic)); ok {
+		header, tail, _ := bytes.Cut(rest, []byte("\n"))
+		format, body = string(header), tail
+	}
+	var snapshot engine.SaveGame
+	codec, ok := saveCodecs[format]
+	if !ok {
+		return snapshot, fmt.Errorf("unknown save format %q", format)
+	}
+	err := codec.Unmarshal(body, &snapshot)
+	return snapshot, err
+}
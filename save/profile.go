@@ -0,0 +1,152 @@
+package save
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	profilesDir     = "profiles"
+	profileFile     = "profile.json"
+	profileConfig   = "config.yml"
+	defaultSaveFile = "savegame.json"
+	slotExtension   = ".json"
+	settingsFile    = "settings.yml"
+)
+
+type ProfileMode string
+
+const (
+	ProfileNormal   ProfileMode = "normal"
+	ProfileHardcore ProfileMode = "hardcore"
+)
+
+type Profile struct {
+	Name      string      `json:"name"`
+	Mode      ProfileMode `json:"mode"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Ended     bool        `json:"ended"`
+	EndReason string      `json:"endReason,omitempty"`
+	EndedAt   time.Time   `json:"endedAt,omitempty"`
+}
+
+func OpenProfile(name string, mode ProfileMode) (Profile, error) {
+	if name == "" {
+		if mode == ProfileHardcore {
+			return Profile{}, fmt.Errorf("hardcore mode requires a named profile")
+		}
+		return Profile{Mode: ProfileNormal}, nil
+	}
+	profile, err := loadProfile(name)
+	if err == nil {
+		return profile, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return Profile{}, err
+	}
+	profile = Profile{Name: name, Mode: mode, CreatedAt: time.Now()}
+	if err := profile.Save(); err != nil {
+		return Profile{}, err
+	}
+	return profile, nil
+}
+
+func FindProfile(name string) (Profile, error) {
+	if name == "" {
+		return Profile{Mode: ProfileNormal}, nil
+	}
+	profile, err := loadProfile(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return Profile{}, fmt.Errorf("profile %s does not exist", name)
+	}
+	return profile, err
+}
+
+func loadProfile(name string) (Profile, error) {
+	payload, err := os.ReadFile(filepath.Join(profilesDir, name, profileFile))
+	if err != nil {
+		return Profile{}, fmt.Errorf("read profile: %w", err)
+	}
+	var profile Profile
+	if err := json.Unmarshal(payload, &profile); err != nil {
+		return Profile{}, fmt.Errorf("parse profile: %w", err)
+	}
+	if profile.Mode == "" {
+		profile.Mode = ProfileNormal
+	}
+	profile.Name = name
+	return profile, nil
+}
+
+func (p Profile) Save() error {
+	if p.Name == "" {
+		return nil
+	}
+	if err := os.MkdirAll(p.Dir(), 0o755); err != nil {
+		return fmt.Errorf("create profile dir: %w", err)
+	}
+	payload, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serialize profile: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(p.Dir(), profileFile), payload, 0o644); err != nil {
+		return fmt.Errorf("write profile: %w", err)
+	}
+	return nil
+}
+
+func (p Profile) Dir() string {
+	if p.Name == "" {
+		return "."
+	}
+	return filepath.Join(profilesDir, p.Name)
+}
+
+func (p Profile) SavePath() string {
+	return filepath.Join(p.Dir(), defaultSaveFile)
+}
+
+func (p Profile) SlotPath(slot string) string {
+	return filepath.Join(p.Dir(), slot+slotExtension)
+}
+
+func (p Profile) Slots() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(p.Dir(), "*"+slotExtension))
+	if err != nil {
+		return nil, fmt.Errorf("list slots: %w", err)
+	}
+	slots := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if name := filepath.Base(path); name != defaultSaveFile && name != profileFile {
+			slots = append(slots, strings.TrimSuffix(name, slotExtension))
+		}
+	}
+	return slots, nil
+}
+
+func (p Profile) ConfigPath() string {
+	if p.Name == "" {
+		return ""
+	}
+	return filepath.Join(p.Dir(), profileConfig)
+}
+
+func (p Profile) Hardcore() bool {
+	return p.Mode == ProfileHardcore
+}
+
+func (p *Profile) End(reason string, now time.Time) error {
+	p.Ended = true
+	p.EndReason = reason
+	p.EndedAt = now
+	return p.Save()
+}
+
+func (p Profile) SettingsPath() string {
+	return filepath.Join(p.Dir(), settingsFile)
+}
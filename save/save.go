@@ -0,0 +1,80 @@
+package save
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+func Write(g *engine.Engine, path string) error {
+	if err := backupExisting(path, time.Now()); err != nil {
+		return err
+	}
+	return Autosave(g, path)
+}
+
+func Autosave(g *engine.Engine, path string) error {
+	payload, err := encodeSave(g.SaveFormat, g.Capture())
+	if err != nil {
+		return fmt.Errorf("serialize save: %w", err)
+	}
+	if err := os.WriteFile(path, payload, 0o644); err != nil {
+		return fmt.Errorf("write save: %w", err)
+	}
+	return nil
+}
+
+func Load(g *engine.Engine, path string) error {
+	return LoadBackup(g, path, path)
+}
+
+func LoadBackup(g *engine.Engine, path, savePath string) error {
+	snapshot, err := readSave(path)
+	if err != nil {
+		return err
+	}
+	if err := backupLive(g, savePath, time.Now()); err != nil {
+		return err
+	}
+	return g.Restore(snapshot)
+}
+
+func Resume(game *engine.Engine, profile Profile) error {
+	snapshot, err := readSave(profile.SavePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return game.Restore(snapshot)
+}
+
+func readSave(path string) (engine.SaveGame, error) {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return engine.SaveGame{}, fmt.Errorf("read save: %w", err)
+	}
+	snapshot, err := decodeSave(payload)
+	if err != nil {
+		return engine.SaveGame{}, fmt.Errorf("parse save: %w", err)
+	}
+	return snapshot, nil
+}
+
+func LoadGhost(path string) (*engine.GhostRun, error) {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read ghost: %w", err)
+	}
+	snapshot, err := decodeSave(payload)
+	if err != nil {
+		return nil, fmt.Errorf("parse ghost: %w", err)
+	}
+	return engine.NewGhost(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), snapshot.Stats)
+}
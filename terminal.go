@@ -0,0 +1,18 @@
+package main
+
+import (
+	"os"
+	"runtime"
+)
+
+func InteractiveTerminal() bool {
+	if term := os.Getenv("TERM"); runtime.GOOS != "windows" && (term == "" || term == "dumb") {
+		return false
+	}
+	return isCharDevice(os.Stdin) && isCharDevice(os.Stdout)
+}
+
+func isCharDevice(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+const achievementBarWidth = 20
+
+func (ui *UI) openAchievements() {
+	ui.achievementScroll = 0
+	ui.mode = modeAchievements
+}
+
+func (ui *UI) handleAchievementsKey(event *tcell.EventKey) {
+	switch event.Key() {
+	case tcell.KeyUp:
+		ui.achievementScroll = engine.MaxInt(ui.achievementScroll-1, 0)
+	case tcell.KeyDown:
+		ui.achievementScroll = engine.MinInt(ui.achievementScroll+1, len(engine.Achievements)-1)
+	default:
+		ui.mode = modeMain
+	}
+}
+
+func (ui *UI) drawAchievements(width, height int) {
+	unlocked := len(ui.game.Stats.Achievements)
+	ui.drawText(2, 1, tr("Achievements - %d of %d unlocked", unlocked, len(engine.Achievements)), tcell.StyleDefault.Bold(true))
+	ui.drawText(2, height-2, truncate(tr("↑/↓ scroll | any other key returns"), width-4), ui.palette().good)
+	rows := (height - 5) / 2
+	for index := ui.achievementScroll; index < len(engine.Achievements) && index-ui.achievementScroll < rows; index++ {
+		y := 3 + (index-ui.achievementScroll)*2
+		ui.drawAchievement(4, y, width-8, engine.Achievements[index])
+	}
+}
+
+func (ui *UI) drawAchievement(x, y, width int, achievement engine.Achievement) {
+	at, done := ui.game.Stats.Achievements[achievement.Key]
+	marker, style := "[ ]", ui.palette().locked
+	if done {
+		marker, style = "[x]", ui.palette().good
+	}
+	ui.drawText(x, y, truncate(fmt.Sprintf("%s %s - %s", marker, achievement.Name, tr(achievement.Description)), width), style)
+	if done {
+		ui.drawText(x+4, y+1, truncate(tr("unlocked %s", at.Format("2006-01-02 15:04")), width-4), ui.palette().base)
+		return
+	}
+	progress := ui.game.AchievementProgress(achievement)
+	filled := progress * achievementBarWidth / achievement.Target
+	bar := fmt.Sprintf("[%s%s] %s/%s", strings.Repeat("#", filled), strings.Repeat(".", achievementBarWidth-filled), ui.formatNumber(progress), ui.formatNumber(achievement.Target))
+	ui.drawText(x+4, y+1, truncate(bar, width-4), ui.palette().accent)
+}
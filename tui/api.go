@@ -0,0 +1,168 @@
+package tui
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+const APITokenEnv = "GO_GAME_API_TOKEN"
+
+type apiCall struct {
+	run   func(ui *UI) (int, any)
+	reply chan apiReply
+}
+
+type apiReply struct {
+	status int
+	body   any
+}
+
+type apiProjection struct {
+	Seconds   int            `json:"seconds"`
+	Resources map[string]int `json:"resources"`
+}
+
+type apiResult struct {
+	OK      bool               `json:"ok"`
+	Message string             `json:"message"`
+	Class   engine.StatusClass `json:"-"`
+}
+
+func (ui *UI) StartAPI(addr, token string) (*http.Server, error) {
+	if token == "" {
+		return nil, fmt.Errorf("api token required (-api-token or %s)", APITokenEnv)
+	}
+	dashboard, err := dashboardHandler()
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listen api: %w", err)
+	}
+	if ui.apiCalls == nil {
+		ui.apiCalls = make(chan apiCall)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/state", ui.apiHandler(token, nil, (*UI).apiState))
+	mux.HandleFunc("POST /api/buy", ui.apiHandler(token, &engine.Target{}, nil))
+	mux.HandleFunc("POST /api/upgrade", ui.apiHandler(token, &engine.Target{}, nil))
+	mux.HandleFunc("POST /api/run", ui.apiHandler(token, &engine.Target{}, nil))
+	mux.HandleFunc("POST /api/save", ui.apiHandler(token, nil, (*UI).apiSave))
+	mux.HandleFunc("GET /api/projection", ui.projectionHandler(token))
+	mux.HandleFunc("GET /api/stream", ui.streamHandler(token))
+	mux.Handle("GET /", dashboard)
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	return server, nil
+}
+
+func (ui *UI) apiHandler(token string, target *engine.Target, run func(ui *UI) (int, any)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(r, token) {
+			writeJSON(w, http.StatusUnauthorized, apiResult{Message: "invalid token"})
+			return
+		}
+		call := run
+		if target != nil {
+			var body engine.Target
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				writeJSON(w, http.StatusBadRequest, apiResult{Message: fmt.Sprintf("parse request: %v", err)})
+				return
+			}
+			kind := strings.TrimPrefix(r.URL.Path, "/api/")
+			call = func(ui *UI) (int, any) { return ui.apiAction(kind, body) }
+		}
+		if result, ok := ui.callAPI(r.Context(), call); ok {
+			writeJSON(w, result.status, result.body)
+		}
+	}
+}
+
+func authorized(r *http.Request, token string) bool {
+	supplied := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if supplied == "" {
+		supplied = r.URL.Query().Get("token")
+	}
+	return subtle.ConstantTimeCompare([]byte(supplied), []byte(token)) == 1
+}
+
+func (ui *UI) callAPI(ctx context.Context, run func(ui *UI) (int, any)) (apiReply, bool) {
+	reply := make(chan apiReply, 1)
+	select {
+	case ui.apiCalls <- apiCall{run: run, reply: reply}:
+	case <-ctx.Done():
+		return apiReply{}, false
+	}
+	select {
+	case result := <-reply:
+		return result, true
+	case <-ctx.Done():
+		return apiReply{}, false
+	}
+}
+
+func (ui *UI) serveAPI(call apiCall) {
+	status, body := call.run(ui)
+	call.reply <- apiReply{status: status, body: body}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func (ui *UI) apiState() (int, any) {
+	state := ui.game.Snapshot()
+	state.Paused = ui.clock.paused
+	return http.StatusOK, state
+}
+
+func (ui *UI) projectionHandler(token string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		seconds := r.URL.Query().Get("seconds")
+		ui.apiHandler(token, nil, func(ui *UI) (int, any) { return ui.apiProjection(seconds) })(w, r)
+	}
+}
+
+func (ui *UI) apiProjection(value string) (int, any) {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return http.StatusBadRequest, apiResult{Message: "seconds must be a non-negative integer"}
+	}
+	at := ui.game.Now().Add(time.Duration(seconds) * time.Second)
+	return http.StatusOK, apiProjection{Seconds: seconds, Resources: ui.game.ProjectResources(at)}
+}
+
+func (ui *UI) apiAction(kind string, target engine.Target) (int, any) {
+	if ui.game.Replaying() {
+		return http.StatusConflict, apiResult{Message: tr("replay playback is read-only"), Class: engine.StatusError}
+	}
+	status, ok := ui.game.Perform(engine.BotAction{Kind: kind, Target: target})
+	if !ok {
+		return http.StatusNotFound, apiResult{Message: status.Message, Class: status.Class}
+	}
+	return ui.apiResult(status)
+}
+
+func (ui *UI) apiSave() (int, any) {
+	return ui.apiResult(ui.guardDevMode("save", ui.saveGame))
+}
+
+func (ui *UI) apiResult(status engine.Status) (int, any) {
+	ui.setStatus(status)
+	if status.Failed() {
+		return http.StatusConflict, apiResult{Message: status.Message, Class: status.Class}
+	}
+	return http.StatusOK, apiResult{OK: true, Message: status.Message, Class: status.Class}
+}
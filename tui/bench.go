@@ -0,0 +1,21 @@
+package tui
+
+import (
+	"testing"
+
+	"archuser.org/go-game/engine"
+)
+
+func BenchmarkRender(game *engine.Engine, width, height int) (testing.BenchmarkResult, error) {
+	harness, err := NewHarness(game, width, height)
+	if err != nil {
+		return testing.BenchmarkResult{}, err
+	}
+	defer harness.Close()
+	return testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			harness.Render()
+		}
+	}), nil
+}
@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+
+	"archuser.org/go-game/engine"
+)
+
+var rtlScripts = []*unicode.RangeTable{unicode.Hebrew, unicode.Arabic, unicode.Syriac, unicode.Thaana, unicode.Nko}
+
+type glyph struct {
+	char  rune
+	marks string
+	width int
+}
+
+func isRTL(char rune) bool {
+	return unicode.In(char, rtlScripts...)
+}
+
+func isNeutral(char rune) bool {
+	return unicode.IsSpace(char) || unicode.IsPunct(char) || unicode.IsSymbol(char)
+}
+
+func glyphs(text string) []glyph {
+	return appendGlyphs(make([]glyph, 0, len(text)), text)
+}
+
+func appendGlyphs(result []glyph, text string) []glyph {
+	state := -1
+	for text != "" {
+		var cluster string
+		var width int
+		cluster, text, width, state = uniseg.FirstGraphemeClusterInString(text, state)
+		char, size := utf8.DecodeRuneInString(cluster)
+		result = append(result, glyph{char: char, marks: cluster[size:], width: engine.MaxInt(width, 1)})
+	}
+	return result
+}
+
+func stringWidth(text string) int {
+	if plainASCII(text) {
+		return len(text)
+	}
+	width, state := 0, -1
+	for text != "" {
+		var cluster int
+		_, text, cluster, state = uniseg.FirstGraphemeClusterInString(text, state)
+		width += engine.MaxInt(cluster, 1)
+	}
+	return width
+}
+
+func plainASCII(text string) bool {
+	for index := 0; index < len(text); index++ {
+		if text[index] < ' ' || text[index] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func glyphsWidth(chars []glyph) int {
+	width := 0
+	for _, char := range chars {
+		width += char.width
+	}
+	return width
+}
+
+func fitGlyphs(chars []glyph, width int) []glyph {
+	used := 0
+	for index, char := range chars {
+		if used+char.width > width {
+			return chars[:index]
+		}
+		used += char.width
+	}
+	return chars
+}
+
+func glyphString(chars []glyph) string {
+	text := make([]rune, 0, len(chars))
+	for _, char := range chars {
+		text = append(append(text, char.char), []rune(char.marks)...)
+	}
+	return string(text)
+}
+
+func visualOrder(line []glyph) []glyph {
+	for start := 0; start < len(line); start++ {
+		if !isRTL(line[start].char) {
+			continue
+		}
+		end := rtlRunEnd(line, start)
+		for left, right := start, end-1; left < right; left, right = left+1, right-1 {
+			line[left], line[right] = line[right], line[left]
+		}
+		start = end - 1
+	}
+	return line
+}
+
+func rtlRunEnd(line []glyph, start int) int {
+	end := start + 1
+	for index := start + 1; index < len(line); index++ {
+		char := line[index].char
+		if isRTL(char) {
+			end = index + 1
+			continue
+		}
+		if !isNeutral(char) {
+			break
+		}
+	}
+	return end
+}
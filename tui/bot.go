@@ -0,0 +1,20 @@
+package tui
+
+import (
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+func (ui *UI) playBot(now time.Time) {
+	if ui.Bot == nil || now.Sub(ui.botAt) < engine.BotInterval {
+		return
+	}
+	ui.botAt = now
+	for _, action := range ui.Bot.Decide(ui.game.Snapshot()) {
+		status, _ := ui.game.Perform(action)
+		if action.Kind != engine.ReplayRun {
+			ui.setStatus(engine.Status{Message: tr("bot: %s", status.Message), Class: status.Class})
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package tui
+
+const buttonRoomMin = 20
+
+type button struct {
+	label  string
+	action action
+}
+
+var workerButtons = []button{
+	{label: "Buy", action: actionBuy},
+	{label: "Run", action: actionRun},
+	{label: "Upgrade", action: actionUpgrade},
+}
+
+func (b button) text() string {
+	return "[" + tr(b.label) + "]"
+}
+
+func (ui *UI) buttonText(b button) string {
+	return ui.labels.get(labelKey{prefix: "[", label: b.label}, b.text)
+}
+
+func (ui *UI) buttonsWidth(buttons []button) int {
+	width := 0
+	for _, item := range buttons {
+		width += textWidth(ui.buttonText(item)) + 1
+	}
+	return width
+}
+
+func (ui *UI) drawButtons(x, y int, buttons []button) {
+	style := ui.palette().accent.Bold(true)
+	for _, item := range buttons {
+		text := ui.buttonText(item)
+		ui.drawText(x, y, text, style)
+		ui.addActionRegion(x, y, textWidth(text), item.action)
+		x += textWidth(text) + 1
+	}
+}
+
+func (ui *UI) drawButtonsRight(right, y, limit int, buttons []button) int {
+	width := ui.buttonsWidth(buttons)
+	if right-width < limit {
+		return right
+	}
+	ui.drawButtons(right-width, y, buttons)
+	return right - width
+}
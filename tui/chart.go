@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+const chartAxisWidth = 9
+
+var chartWindows = []int{60, 120, 300, 600}
+
+func (ui *UI) openChart() {
+	if len(ui.game.Resources) == 0 {
+		ui.setStatus(engine.ErrorStatus(tr("no resources to chart")))
+		return
+	}
+	ui.mode = modeChart
+}
+
+func (ui *UI) handleChartKey(event *tcell.EventKey) {
+	resources := len(ui.game.Resources)
+	switch event.Key() {
+	case tcell.KeyLeft:
+		ui.chartResource = (ui.chartResource + resources - 1) % resources
+	case tcell.KeyRight:
+		ui.chartResource = (ui.chartResource + 1) % resources
+	case tcell.KeyUp:
+		ui.chartZoom = engine.MaxInt(ui.chartZoom-1, 0)
+	case tcell.KeyDown:
+		ui.chartZoom = engine.MinInt(ui.chartZoom+1, len(chartWindows)-1)
+	case tcell.KeyEscape, tcell.KeyEnter:
+		ui.mode = modeMain
+	case tcell.KeyRune:
+		if ui.keys.lookup[event.Rune()] == actionChart {
+			ui.mode = modeMain
+		}
+	}
+}
+
+func (ui *UI) drawChart(width, height int) {
+	resources := engine.SortedKeys(ui.game.Resources)
+	ui.chartResource = clamp(ui.chartResource, 0, len(resources)-1)
+	resource := resources[ui.chartResource]
+	window := chartWindows[ui.chartZoom]
+	values := ui.game.History.Values(resource, window/int(engine.HistoryInterval.Seconds()))
+	title := fmt.Sprintf("Chart - %s (last %dm, %d samples)", resource, window/60, len(values))
+	ui.drawText(2, 1, truncate(title, width-4), tcell.StyleDefault.Bold(true))
+	ui.drawText(2, height-2, truncate("←/→ resource | ↑/↓ zoom | esc close", width-4), ui.palette().good)
+
+	rows := height - 6
+	columns := width - chartAxisWidth - 4
+	if len(values) == 0 || rows <= 0 || columns <= 0 {
+		ui.drawText(2, 3, "no history yet", ui.palette().locked)
+		return
+	}
+	low, high := values[0], values[0]
+	for _, value := range values {
+		low = engine.MinInt(low, value)
+		high = engine.MaxInt(high, value)
+	}
+	ui.drawText(2, 3, truncate(ui.formatNumber(high), chartAxisWidth-1), ui.palette().base)
+	ui.drawText(2, 3+rows-1, truncate(ui.formatNumber(low), chartAxisWidth-1), ui.palette().base)
+	plotX := 2 + chartAxisWidth
+	for y := 3; y < 3+rows; y++ {
+		ui.setCell(plotX-1, y, '│', ui.palette().locked)
+	}
+	for column := 0; column < engine.MinInt(columns, len(values)); column++ {
+		value := values[(column+1)*len(values)/engine.MinInt(columns, len(values))-1]
+		ui.drawChartColumn(plotX+column, 3, rows, chartEighths(value, low, high, rows))
+	}
+}
+
+func chartEighths(value, low, high, rows int) int {
+	if high <= low {
+		return rows * 4
+	}
+	return engine.MaxInt((value-low)*rows*8/(high-low), 1)
+}
+
+func (ui *UI) drawChartColumn(x, top, rows, eighths int) {
+	style := ui.palette().accent
+	for row := 0; row < rows; row++ {
+		filled := eighths - row*8
+		if filled <= 0 {
+			return
+		}
+		glyph := '█'
+		if filled < 8 {
+			glyph = engine.SparkLevels[filled-1]
+		}
+		ui.setCell(x, top+rows-1-row, glyph, style)
+	}
+}
@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+const defaultSpeedIndex = 1
+
+var simSpeeds = []float64{0.5, 1, 2, 4}
+
+type simClock struct {
+	now      time.Time
+	lastReal time.Time
+	shift    int
+	paused   bool
+}
+
+func newSimClock(start time.Time) simClock {
+	return simClock{now: start, lastReal: time.Now()}
+}
+
+func (c *simClock) speed() float64 {
+	return simSpeeds[defaultSpeedIndex+c.shift]
+}
+
+func (c *simClock) advance(real time.Time) time.Time {
+	if c.now.IsZero() {
+		c.now, c.lastReal = real, real
+	}
+	elapsed := real.Sub(c.lastReal)
+	c.lastReal = real
+	if !c.paused && elapsed > 0 {
+		c.now = c.now.Add(time.Duration(float64(elapsed) * c.speed()))
+	}
+	return c.now
+}
+
+func (c *simClock) badge() string {
+	if c.paused {
+		return tr("PAUSED")
+	}
+	if c.shift != 0 {
+		return fmt.Sprintf("%sx", engine.TrimDecimals(c.speed()))
+	}
+	return ""
+}
+
+func (ui *UI) togglePause() {
+	if status, locked := ui.raceLocked(tr("pause")); locked {
+		ui.setStatus(status)
+		return
+	}
+	ui.clock.paused = !ui.clock.paused
+	if ui.clock.paused {
+		ui.setStatus(engine.InfoStatus(tr("paused")))
+		return
+	}
+	ui.setStatus(engine.InfoStatus(tr("resumed")))
+}
+
+func (ui *UI) shiftSpeed(delta int) {
+	if status, locked := ui.raceLocked(tr("speed")); locked {
+		ui.setStatus(status)
+		return
+	}
+	ui.clock.shift = clamp(ui.clock.shift+delta, -defaultSpeedIndex, len(simSpeeds)-1-defaultSpeedIndex)
+	ui.setStatus(engine.InfoStatus(tr("speed %sx", engine.TrimDecimals(ui.clock.speed()))))
+}
+
+type clockKey struct {
+	date     engine.Date
+	session  time.Duration
+	played   time.Duration
+	split    string
+	standing string
+}
+
+func (ui *UI) headerClock(now time.Time) string {
+	key := clockKey{
+		date:     ui.game.Date(),
+		session:  now.Sub(ui.startedAt).Truncate(time.Second),
+		played:   ui.game.Stats.Playtime.Truncate(time.Second),
+		split:    ui.game.GhostSplit(),
+		standing: ui.RaceStanding(),
+	}
+	return ui.texts.clock.get(key, key.text)
+}
+
+func (key clockKey) text() string {
+	clock := key.date.String() + " | " + tr("session %s | played %s", key.session, key.played)
+	if key.split != "" {
+		clock += " | " + key.split
+	}
+	if key.standing != "" {
+		clock += " | " + key.standing
+	}
+	return clock
+}
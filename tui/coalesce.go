@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+const maxEventBatch = 64
+
+func drainEvents(first tcell.Event, events <-chan tcell.Event) []tcell.Event {
+	batch := []tcell.Event{first}
+	for len(batch) < maxEventBatch {
+		select {
+		case ev := <-events:
+			batch = append(batch, ev)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+func (ui *UI) handleEvents(batch []tcell.Event) bool {
+	lastResize := -1
+	for index, ev := range batch {
+		if _, ok := ev.(*tcell.EventResize); ok {
+			lastResize = index
+		}
+	}
+	for index, ev := range batch {
+		if _, ok := ev.(*tcell.EventResize); ok && index != lastResize {
+			continue
+		}
+		if ui.handleEvent(ev) {
+			return true
+		}
+	}
+	return false
+}
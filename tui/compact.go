@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func (ui *UI) drawCompact(width, height int) {
+	tabs := ui.industryTabs()
+	label := ""
+	if ui.game.DevMode {
+		label = "dev"
+	} else if ui.profile.Hardcore() {
+		label = "HC"
+	}
+	if badge := ui.clock.badge(); badge != "" {
+		label = strings.TrimSpace(badge + " " + label)
+	}
+	ui.drawTabs(0, 0, width-len(label)-2, tabs)
+	if label != "" {
+		ui.drawText(width-len(label)-1, 0, label, tcell.StyleDefault.Bold(true))
+	}
+
+	rates := ui.currentRates()
+	parts := make([]string, 0, len(ui.game.Resources)+1)
+	parts = append(parts, fmt.Sprintf("NW %s", ui.formatNumber(ui.game.NetWorth())))
+	for _, resource := range ui.sortedResources() {
+		parts = append(parts, fmt.Sprintf("%s %s %s", ui.resourceLabel(resource), ui.formatNumber(ui.game.Resources[resource]), ui.formatRate(rates[resource])))
+	}
+	ui.drawText(1, 1, truncate(strings.Join(parts, " | "), width-2), tcell.StyleDefault)
+
+	ui.drawWorkers(1, 2, width-1, height-3)
+	ui.drawStatus(1, height-2, ui.drawSaveIndicator(width-2, height-2, buttonRoomMin))
+	ui.drawFooterItems(1, height-1, width-1, []footerItem{
+		ui.footerAction(actionHelp, "help"),
+		ui.footerAction(actionBuy, "buy"),
+		ui.footerAction(actionRun, "run"),
+		ui.footerAction(actionUpgrade, "upg"),
+		ui.footerAction(actionBuyMode, "mode"),
+		{label: tr("esc menu")},
+	})
+}
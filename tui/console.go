@@ -0,0 +1,293 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+var slotName = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+var consoleCommands = []plainCommand{
+	{"save", "save the game, optionally to a named slot", (*UI).consoleSave},
+	{"load", "load the game, optionally from a named slot", (*UI).consoleLoad},
+	{"goto", "jump to an industry or worker by key or name", (*UI).consoleGoto},
+	{"buy", "buy a worker by key or name, optionally a count", (*UI).consoleBuy},
+	{"speed", "set simulation speed", (*UI).consoleSpeed},
+	{"ghost", "race the milestones of a saved slot", (*UI).consoleGhost},
+}
+
+func (ui *UI) openConsole() {
+	ui.consoleInput = ""
+	ui.mode = modeConsole
+}
+
+func (ui *UI) handleConsoleKey(event *tcell.EventKey) {
+	switch event.Key() {
+	case tcell.KeyEnter:
+		ui.mode = modeMain
+		before := ui.lastStatusAt
+		if status := ui.runConsole(ui.consoleInput); status.Message != "" && ui.mode == modeMain && ui.lastStatusAt.Equal(before) {
+			ui.setStatus(status)
+		}
+	case tcell.KeyEscape:
+		ui.mode = modeMain
+	case tcell.KeyTab:
+		ui.completeConsole()
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if runes := []rune(ui.consoleInput); len(runes) > 0 {
+			ui.consoleInput = string(runes[:len(runes)-1])
+		} else {
+			ui.mode = modeMain
+		}
+	case tcell.KeyRune:
+		ui.consoleInput += string(event.Rune())
+	}
+}
+
+func (ui *UI) runConsole(line string) engine.Status {
+	fields := strings.Fields(strings.ToLower(line))
+	if len(fields) == 0 {
+		return engine.Status{}
+	}
+	if command, ok := findConsoleCommand(fields[0]); ok {
+		return command.run(ui, fields[1:])
+	}
+	return engine.ErrorStatus(tr("unknown command %q", fields[0]))
+}
+
+func findConsoleCommand(name string) (plainCommand, bool) {
+	for _, commands := range [][]plainCommand{consoleCommands, plainCommands} {
+		for _, command := range commands {
+			if command.name == name && command.run != nil {
+				return command, true
+			}
+		}
+	}
+	return plainCommand{}, false
+}
+
+func (ui *UI) consoleSave(args []string) engine.Status {
+	if status, blocked := ui.blocked(actionSave); blocked {
+		return status
+	}
+	path, err := ui.slotPath(args)
+	if err != nil {
+		return engine.ErrorStatus(err.Error())
+	}
+	return ui.guardDevMode("save", func() engine.Status { return ui.saveTo(path) })
+}
+
+func (ui *UI) consoleLoad(args []string) engine.Status {
+	if status, blocked := ui.blocked(actionLoad); blocked {
+		return status
+	}
+	path, err := ui.slotPath(args)
+	if err != nil {
+		return engine.ErrorStatus(err.Error())
+	}
+	return ui.guardDevMode("load", ui.guardHardcore("load", func() engine.Status { return ui.loadFrom(path) }))
+}
+
+func (ui *UI) slotPath(args []string) (string, error) {
+	if len(args) == 0 {
+		return ui.profile.SavePath(), nil
+	}
+	if !slotName.MatchString(args[0]) {
+		return "", errors.New(tr("slot names use letters, digits, - and _"))
+	}
+	return ui.profile.SlotPath(args[0]), nil
+}
+
+func (ui *UI) consoleGoto(args []string) engine.Status {
+	if len(args) == 0 {
+		return engine.ErrorStatus(tr("usage: goto <industry|worker>"))
+	}
+	for index, industry := range ui.game.Industries {
+		if matchesName(args[0], industry.Key, industry.Name) {
+			ui.selectIndustry(index)
+			return engine.InfoStatus(tr("industry %s", industry.Name))
+		}
+	}
+	industryIndex, workerIndex, ok := ui.findWorker(args[0])
+	if !ok {
+		return engine.ErrorStatus(tr("no industry or worker named %s", args[0]))
+	}
+	ui.selectWorker(industryIndex, workerIndex)
+	return engine.InfoStatus(ui.plainWorkerLine(ui.game.Industries[industryIndex].Workers[workerIndex]))
+}
+
+func (ui *UI) consoleBuy(args []string) engine.Status {
+	if len(args) == 0 {
+		return plainAction(actionBuy)(ui, args)
+	}
+	industryIndex, workerIndex, count, status, ok := ui.parseBuy(args)
+	if !ok {
+		return status
+	}
+	if cost, confirm := ui.buyConfirmed(industryIndex, workerIndex, count); confirm {
+		ui.confirmBuy(industryIndex, workerIndex, count, cost)
+		return ui.plainOutcome()
+	}
+	return ui.game.BuyCount(industryIndex, workerIndex, count)
+}
+
+func (ui *UI) parseBuy(args []string) (int, int, int, engine.Status, bool) {
+	if status, blocked := ui.blocked(actionBuy); blocked {
+		return 0, 0, 0, status, false
+	}
+	industryIndex, workerIndex, ok := ui.findWorker(args[0])
+	if !ok {
+		return 0, 0, 0, engine.ErrorStatus(tr("no worker named %s", args[0])), false
+	}
+	count := 1
+	if len(args) > 1 {
+		parsed, err := strconv.Atoi(args[1])
+		if err != nil || parsed < 1 {
+			return 0, 0, 0, engine.ErrorStatus(tr("usage: buy <worker> [count]")), false
+		}
+		count = parsed
+	}
+	ui.selectWorker(industryIndex, workerIndex)
+	return industryIndex, workerIndex, count, engine.Status{}, true
+}
+
+func (ui *UI) consoleSpeed(args []string) engine.Status {
+	if len(args) == 0 {
+		return engine.ErrorStatus(tr("usage: speed <%s>", speedChoices()))
+	}
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(args[0], "x"), 64)
+	for index, candidate := range simSpeeds {
+		if err == nil && candidate == speed {
+			return ui.setSpeed(index - defaultSpeedIndex)
+		}
+	}
+	return engine.ErrorStatus(tr("usage: speed <%s>", speedChoices()))
+}
+
+func (ui *UI) setSpeed(shift int) engine.Status {
+	act := actionFaster
+	if shift < ui.clock.shift {
+		act = actionSlower
+	}
+	if status, blocked := ui.blocked(act); blocked {
+		return status
+	}
+	ui.shiftSpeed(shift - ui.clock.shift)
+	return engine.Status{}
+}
+
+func speedChoices() string {
+	choices := make([]string, 0, len(simSpeeds))
+	for _, speed := range simSpeeds {
+		choices = append(choices, engine.TrimDecimals(speed))
+	}
+	return strings.Join(choices, "|")
+}
+
+func matchesName(query, key, name string) bool {
+	return query == strings.ToLower(key) || query == consoleName(name)
+}
+
+func consoleName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), " ", "-")
+}
+
+func (ui *UI) findWorker(query string) (int, int, bool) {
+	order := []int{ui.activeIndustry}
+	for index := range ui.game.Industries {
+		if index != ui.activeIndustry {
+			order = append(order, index)
+		}
+	}
+	for _, industryIndex := range order {
+		for workerIndex, worker := range ui.game.Industries[industryIndex].Workers {
+			if matchesName(query, worker.Definition.Key, worker.Definition.WorkerName) {
+				return industryIndex, workerIndex, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func (ui *UI) selectWorker(industryIndex, workerIndex int) {
+	if industryIndex != ui.activeIndustry {
+		ui.selectIndustry(industryIndex)
+	}
+	ui.selectedWorker = workerIndex
+	ui.ensureSelectionVisible()
+}
+
+func (ui *UI) completeConsole() {
+	fields := strings.Fields(ui.consoleInput)
+	if len(fields) == 0 || strings.HasSuffix(ui.consoleInput, " ") {
+		fields = append(fields, "")
+	}
+	word := strings.ToLower(fields[len(fields)-1])
+	candidates := ui.consoleWords(len(fields) == 1)
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, word) {
+			matches = append(matches, candidate)
+		}
+	}
+	if len(matches) == 0 {
+		return
+	}
+	fields[len(fields)-1] = commonPrefix(matches)
+	ui.consoleInput = strings.Join(fields, " ")
+	if len(matches) == 1 {
+		ui.consoleInput += " "
+		return
+	}
+	ui.setStatus(engine.InfoStatus(strings.Join(matches, " ")))
+}
+
+func (ui *UI) consoleWords(commands bool) []string {
+	seen := make(map[string]bool)
+	if commands {
+		for _, list := range [][]plainCommand{consoleCommands, plainCommands} {
+			for _, command := range list {
+				if command.run != nil {
+					seen[command.name] = true
+				}
+			}
+		}
+	} else {
+		for _, industry := range ui.game.Industries {
+			seen[strings.ToLower(industry.Key)] = true
+			seen[consoleName(industry.Name)] = true
+			for _, worker := range industry.Workers {
+				seen[strings.ToLower(worker.Definition.Key)] = true
+				seen[consoleName(worker.Definition.WorkerName)] = true
+			}
+		}
+	}
+	words := make([]string, 0, len(seen))
+	for word := range seen {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	return words
+}
+
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, word := range words[1:] {
+		for !strings.HasPrefix(word, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+func (ui *UI) consolePrompt() string {
+	return fmt.Sprintf(":%s_", ui.consoleInput)
+}
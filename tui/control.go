@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+const controlTimeout = 2 * time.Second
+
+var controlCommands = []plainCommand{
+	{"status", "print one line of resources, rates and clock state", (*UI).controlStatus},
+	{"pause", "pause or resume the simulation", plainAction(actionPause)},
+	{"buy", "buy the selected worker, or buy <worker> [count]; large buys need confirmation in the game", (*UI).controlBuy},
+	{"help", "list commands", nil},
+}
+
+func (ui *UI) StartControl(path string) (net.Listener, error) {
+	if err := clearStaleControl(path); err != nil {
+		return nil, err
+	}
+	listener, err := listenControl(path)
+	if err != nil {
+		return nil, fmt.Errorf("listen control: %w", err)
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("restrict control socket: %w", err)
+	}
+	if ui.apiCalls == nil {
+		ui.apiCalls = make(chan apiCall)
+	}
+	go ui.acceptControl(listener)
+	return listener, nil
+}
+
+func clearStaleControl(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSocket == 0 {
+		return nil
+	}
+	if conn, err := net.DialTimeout("unix", path, controlTimeout); err == nil {
+		conn.Close()
+		return fmt.Errorf("control socket %s is in use by another instance", path)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("remove stale control socket: %w", err)
+	}
+	return nil
+}
+
+func (ui *UI) acceptControl(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go ui.serveControl(conn)
+	}
+}
+
+func (ui *UI) serveControl(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		line := scanner.Text()
+		ctx, cancel := context.WithTimeout(context.Background(), controlTimeout)
+		reply, ok := ui.callAPI(ctx, func(ui *UI) (int, any) { return 0, ui.runControl(line) })
+		cancel()
+		if !ok {
+			fmt.Fprintln(conn, "error: game loop busy")
+			continue
+		}
+		fmt.Fprintln(conn, reply.body)
+	}
+}
+
+func (ui *UI) runControl(line string) string {
+	fields := strings.Fields(strings.ToLower(line))
+	if len(fields) == 0 {
+		return ""
+	}
+	if fields[0] == "help" {
+		return controlHelp()
+	}
+	for _, command := range controlCommands {
+		if command.name == fields[0] && command.run != nil {
+			return command.run(ui, fields[1:]).Message
+		}
+	}
+	before := ui.lastStatusAt
+	status := ui.runConsole(line)
+	if status.Message != "" && ui.lastStatusAt.Equal(before) {
+		ui.setStatus(status)
+	}
+	if status.Message == "" {
+		return ui.statusMessage
+	}
+	return status.Message
+}
+
+func (ui *UI) controlStatus(args []string) engine.Status {
+	status := fmt.Sprintf("%s Net worth %s.", ui.plainResources(), ui.formatNumber(ui.game.NetWorth()))
+	if badge := ui.clock.badge(); badge != "" {
+		status += " " + badge
+	}
+	return engine.InfoStatus(status)
+}
+
+func (ui *UI) controlBuy(args []string) engine.Status {
+	industryIndex, workerIndex, count := ui.activeIndustry, ui.selectedWorker, 0
+	var cost map[string]int
+	confirm := false
+	if len(args) == 0 {
+		if status, blocked := ui.blocked(actionBuy); blocked {
+			return status
+		}
+		count, cost = ui.game.PlanBuy(industryIndex, workerIndex)
+		confirm = count > 0 && ui.needsConfirm(cost, ui.game.BuyModeMax)
+	} else {
+		var status engine.Status
+		var ok bool
+		if industryIndex, workerIndex, count, status, ok = ui.parseBuy(args); !ok {
+			return status
+		}
+		cost, confirm = ui.buyConfirmed(industryIndex, workerIndex, count)
+	}
+	if confirm {
+		name := ui.game.Industries[industryIndex].Workers[workerIndex].Definition.WorkerName
+		return engine.ErrorStatus(tr("buying %s %s (%s) needs confirmation in the game", ui.formatNumber(count), name, strings.Join(ui.costSummary(cost), ", ")))
+	}
+	status := ui.game.BuyCount(industryIndex, workerIndex, count)
+	ui.setStatus(status)
+	return status
+}
+
+func controlHelp() string {
+	names := make([]string, 0, len(controlCommands)+len(consoleCommands))
+	for _, commands := range [][]plainCommand{controlCommands, consoleCommands, plainCommands} {
+		for _, command := range commands {
+			if (command.run != nil || command.name == "help") && !slices.Contains(names, command.name) {
+				names = append(names, command.name)
+			}
+		}
+	}
+	return strings.Join(names, " ")
+}
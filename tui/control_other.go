@@ -0,0 +1,11 @@
+//go:build !unix
+
+package tui
+
+import (
+	"net"
+)
+
+func listenControl(path string) (net.Listener, error) {
+	return net.Listen("unix", path)
+}
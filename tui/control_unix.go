@@ -0,0 +1,14 @@
+//go:build unix
+
+package tui
+
+import (
+	"net"
+	"syscall"
+)
+
+func listenControl(path string) (net.Listener, error) {
+	mask := syscall.Umask(0o077)
+	defer syscall.Umask(mask)
+	return net.Listen("unix", path)
+}
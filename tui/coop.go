@@ -0,0 +1,284 @@
+package tui
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+const (
+	coopInterval  = 250 * time.Millisecond
+	coopQueue     = 16
+	noticeCoop    = "co-op"
+	coopHello     = "hello"
+	coopCursor    = "cursor"
+	coopAction    = "action"
+	coopState     = "state"
+	coopLog       = "log"
+	coopWelcome   = "welcome"
+	coopDenied    = "denied"
+	defaultPlayer = "player"
+	CoopTokenEnv  = "GO_GAME_COOP_TOKEN"
+)
+
+type coopMessage struct {
+	Type     string             `json:"type"`
+	Name     string             `json:"name,omitempty"`
+	Token    string             `json:"token,omitempty"`
+	Kind     string             `json:"kind,omitempty"`
+	Industry string             `json:"industry,omitempty"`
+	Worker   string             `json:"worker,omitempty"`
+	Count    int                `json:"count,omitempty"`
+	Message  string             `json:"message,omitempty"`
+	Class    engine.StatusClass `json:"class,omitempty"`
+	State    *coopStateFrame    `json:"state,omitempty"`
+}
+
+type coopStateFrame struct {
+	Resources map[string]int `json:"resources"`
+	Workers   []coopWorker   `json:"workers"`
+	Players   []coopPlayer   `json:"players"`
+}
+
+type coopWorker struct {
+	Industry  string        `json:"industry"`
+	Worker    string        `json:"worker"`
+	Owned     int           `json:"owned"`
+	Tier      int           `json:"tier"`
+	Auto      bool          `json:"auto"`
+	Running   bool          `json:"running"`
+	Remaining time.Duration `json:"remaining"`
+}
+
+type coopPlayer struct {
+	Name     string `json:"name"`
+	Industry string `json:"industry"`
+	Worker   string `json:"worker"`
+}
+
+type CoopServer struct {
+	ui       *UI
+	listener net.Listener
+	token    string
+	mu       sync.Mutex
+	clients  map[*coopPeer]bool
+	done     chan struct{}
+}
+
+type coopPeer struct {
+	conn   net.Conn
+	out    chan coopMessage
+	player coopPlayer
+}
+
+func (ui *UI) StartCoop(addr, token string) (*CoopServer, error) {
+	if token == "" {
+		return nil, fmt.Errorf("co-op token required (-coop-token or %s)", CoopTokenEnv)
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listen co-op: %w", err)
+	}
+	if ui.apiCalls == nil {
+		ui.apiCalls = make(chan apiCall)
+	}
+	server := &CoopServer{ui: ui, listener: listener, token: token, clients: make(map[*coopPeer]bool), done: make(chan struct{})}
+	go server.accept()
+	go server.broadcastLoop()
+	return server, nil
+}
+
+func (s *CoopServer) Close() error {
+	close(s.done)
+	return s.listener.Close()
+}
+
+func (s *CoopServer) accept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		peer := &coopPeer{conn: conn, out: make(chan coopMessage, coopQueue), player: coopPlayer{Name: defaultPlayer}}
+		go peer.write()
+		go s.serve(peer)
+	}
+}
+
+func (p *coopPeer) write() {
+	encoder := json.NewEncoder(p.conn)
+	for message := range p.out {
+		if err := encoder.Encode(message); err != nil {
+			p.conn.Close()
+			return
+		}
+	}
+}
+
+func (s *CoopServer) serve(peer *coopPeer) {
+	defer s.leave(peer)
+	scanner := bufio.NewScanner(peer.conn)
+	for scanner.Scan() {
+		var message coopMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			continue
+		}
+		switch message.Type {
+		case coopHello:
+			if subtle.ConstantTimeCompare([]byte(message.Token), []byte(s.token)) != 1 {
+				json.NewEncoder(peer.conn).Encode(coopMessage{Type: coopDenied, Message: tr("invalid co-op token")})
+				return
+			}
+			s.join(peer, message.Name)
+		case coopCursor:
+			if !s.joined(peer) {
+				continue
+			}
+			s.mu.Lock()
+			peer.player.Industry, peer.player.Worker = message.Industry, message.Worker
+			s.mu.Unlock()
+		case coopAction:
+			if s.joined(peer) {
+				s.act(peer, message)
+			}
+		}
+	}
+}
+
+func (s *CoopServer) join(peer *coopPeer, name string) {
+	s.mu.Lock()
+	peer.player.Name = s.uniqueName(name)
+	s.clients[peer] = true
+	s.mu.Unlock()
+	peer.send(coopMessage{Type: coopWelcome, Name: peer.player.Name})
+	s.announce(peer.player.Name, tr("joined"))
+}
+
+func (s *CoopServer) joined(peer *coopPeer) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.clients[peer]
+}
+
+func (s *CoopServer) uniqueName(name string) string {
+	if name == "" {
+		name = defaultPlayer
+	}
+	candidate := name
+	for suffix := 2; s.nameTaken(candidate); suffix++ {
+		candidate = fmt.Sprintf("%s%d", name, suffix)
+	}
+	return candidate
+}
+
+func (s *CoopServer) nameTaken(name string) bool {
+	for peer := range s.clients {
+		if peer.player.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *CoopServer) leave(peer *coopPeer) {
+	s.mu.Lock()
+	joined := s.clients[peer]
+	delete(s.clients, peer)
+	s.mu.Unlock()
+	close(peer.out)
+	peer.conn.Close()
+	if joined {
+		s.announce(peer.player.Name, tr("left"))
+	}
+}
+
+func (s *CoopServer) act(peer *coopPeer, message coopMessage) {
+	target := engine.Target{Industry: message.Industry, Worker: message.Worker, Count: message.Count}
+	result, ok := s.ui.callAPI(context.Background(), func(ui *UI) (int, any) {
+		return ui.apiAction(message.Kind, target)
+	})
+	if !ok {
+		return
+	}
+	reply := result.body.(apiResult)
+	s.announceStatus(peer.player.Name, engine.Status{Message: reply.Message, Class: reply.Class})
+}
+
+func (s *CoopServer) announce(name, message string) {
+	s.announceStatus(name, engine.InfoStatus(message))
+}
+
+func (s *CoopServer) announceStatus(name string, status engine.Status) {
+	s.ui.callAPI(context.Background(), func(ui *UI) (int, any) {
+		ui.appendLog(time.Now(), noticeCoop, tr("%s: %s", name, status.Message))
+		return 0, nil
+	})
+	s.broadcast(coopMessage{Type: coopLog, Name: name, Message: status.Message, Class: status.Class})
+}
+
+func (s *CoopServer) broadcastLoop() {
+	ticker := time.NewTicker(coopInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+		result, ok := s.ui.callAPI(context.Background(), func(ui *UI) (int, any) { return 0, ui.coopFrame() })
+		if !ok {
+			continue
+		}
+		frame := result.body.(coopStateFrame)
+		s.mu.Lock()
+		for peer := range s.clients {
+			frame.Players = append(frame.Players, peer.player)
+		}
+		s.mu.Unlock()
+		s.broadcast(coopMessage{Type: coopState, State: &frame})
+	}
+}
+
+func (s *CoopServer) broadcast(message coopMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for peer := range s.clients {
+		peer.send(message)
+	}
+}
+
+func (p *coopPeer) send(message coopMessage) {
+	select {
+	case p.out <- message:
+	default:
+	}
+}
+
+func (ui *UI) coopFrame() coopStateFrame {
+	frame := coopStateFrame{Resources: engine.CopyResources(ui.game.Resources)}
+	now := ui.game.Now()
+	for _, industry := range ui.game.Industries {
+		for _, worker := range industry.Workers {
+			entry := coopWorker{
+				Industry: industry.Key,
+				Worker:   worker.Definition.Key,
+				Owned:    worker.Owned,
+				Tier:     worker.Tier,
+				Auto:     worker.Auto,
+				Running:  worker.Running,
+			}
+			if worker.Running {
+				entry.Remaining = worker.EndsAt.Sub(now)
+			}
+			frame.Workers = append(frame.Workers, entry)
+		}
+	}
+	return frame
+}
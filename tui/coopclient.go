@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+type coopLink struct {
+	conn     net.Conn
+	encoder  *json.Encoder
+	incoming chan coopMessage
+	name     string
+	cursor   coopPlayer
+	players  []coopPlayer
+	offline  bool
+}
+
+var coopServerActions = map[action]bool{
+	actionSave:   true,
+	actionLoad:   true,
+	actionPause:  true,
+	actionSlower: true,
+	actionFaster: true,
+}
+
+func (ui *UI) JoinCoop(addr, name, token string) error {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("connect co-op: %w", err)
+	}
+	link := &coopLink{conn: conn, encoder: json.NewEncoder(conn), incoming: make(chan coopMessage), name: name}
+	if err := link.encoder.Encode(coopMessage{Type: coopHello, Name: name, Token: token}); err != nil {
+		conn.Close()
+		return fmt.Errorf("send co-op hello: %w", err)
+	}
+	go link.read()
+	ui.coop = link
+	ui.game.Remote = ui.sendCoopAction
+	return nil
+}
+
+func (l *coopLink) read() {
+	defer close(l.incoming)
+	scanner := bufio.NewScanner(l.conn)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var message coopMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err == nil {
+			l.incoming <- message
+		}
+	}
+}
+
+func (ui *UI) coopIncoming() <-chan coopMessage {
+	if ui.coop == nil || ui.coop.offline {
+		return nil
+	}
+	return ui.coop.incoming
+}
+
+func (ui *UI) sendCoop(message coopMessage) error {
+	if ui.coop.offline {
+		return errors.New(tr("not connected to the co-op server"))
+	}
+	if err := ui.coop.encoder.Encode(message); err != nil {
+		ui.coop.offline = true
+		return errors.New(tr("co-op send failed: %v", err))
+	}
+	return nil
+}
+
+func (ui *UI) sendCoopAction(kind string, industryIndex, workerIndex, count int) engine.Status {
+	industry := ui.game.Industries[industryIndex]
+	message := coopMessage{Type: coopAction, Kind: kind, Industry: industry.Key, Worker: industry.Workers[workerIndex].Definition.Key, Count: count}
+	if err := ui.sendCoop(message); err != nil {
+		return engine.ErrorStatus(err.Error())
+	}
+	return engine.InfoStatus(tr("sent %s to the co-op server", kind))
+}
+
+func (ui *UI) syncCoopCursor() {
+	if ui.coop == nil || ui.coop.offline || ui.activeIndustry >= len(ui.game.Industries) {
+		return
+	}
+	industry := ui.game.Industries[ui.activeIndustry]
+	cursor := coopPlayer{Name: ui.coop.name, Industry: industry.Key}
+	if ui.selectedWorker < len(industry.Workers) {
+		cursor.Worker = industry.Workers[ui.selectedWorker].Definition.Key
+	}
+	if cursor == ui.coop.cursor {
+		return
+	}
+	ui.coop.cursor = cursor
+	ui.sendCoop(coopMessage{Type: coopCursor, Industry: cursor.Industry, Worker: cursor.Worker})
+}
+
+func (ui *UI) handleCoop(message coopMessage, ok bool) {
+	if !ok {
+		ui.coop.offline = true
+		ui.setStatus(engine.ErrorStatus(tr("disconnected from the co-op server")))
+		return
+	}
+	switch message.Type {
+	case coopDenied:
+		ui.coop.offline = true
+		ui.setStatus(engine.ErrorStatus(tr("the co-op server refused to join: %s", message.Message)))
+	case coopWelcome:
+		ui.coop.name = message.Name
+		ui.coop.cursor = coopPlayer{}
+		ui.setStatus(engine.SuccessStatus(tr("joined the co-op server as %s", message.Name)))
+	case coopLog:
+		ui.appendLog(time.Now(), noticeCoop, tr("%s: %s", message.Name, message.Message))
+		if message.Name == ui.coop.name {
+			ui.setStatus(engine.Status{Message: message.Message, Class: message.Class})
+		}
+	case coopState:
+		ui.applyCoopFrame(*message.State)
+	}
+}
+
+func (ui *UI) applyCoopFrame(frame coopStateFrame) {
+	now := time.Now()
+	ui.game.LastUpdate = now
+	ui.game.Resources = frame.Resources
+	for _, entry := range frame.Workers {
+		industryIndex, workerIndex, ok := ui.game.FindTarget(entry.Industry, entry.Worker)
+		if !ok {
+			continue
+		}
+		worker := &ui.game.Industries[industryIndex].Workers[workerIndex]
+		worker.Owned, worker.Tier, worker.Auto, worker.Running = entry.Owned, entry.Tier, entry.Auto, entry.Running
+		worker.EndsAt = now.Add(entry.Remaining)
+	}
+	ui.game.WorkersChanged()
+	ui.coop.players = ui.coop.players[:0]
+	for _, player := range frame.Players {
+		if player.Name != ui.coop.name {
+			ui.coop.players = append(ui.coop.players, player)
+		}
+	}
+}
+
+func (ui *UI) coopCursorLabel(industryKey, workerKey string) string {
+	if ui.coop == nil {
+		return ""
+	}
+	var names []string
+	for _, player := range ui.coop.players {
+		if player.Industry == industryKey && player.Worker == workerKey {
+			names = append(names, "@"+player.Name)
+		}
+	}
+	return strings.Join(names, " ")
+}
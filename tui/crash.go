@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"runtime/debug"
+
+	"archuser.org/go-game/engine"
+	"archuser.org/go-game/save"
+)
+
+const emergencySlot = "emergency"
+
+func (ui *UI) Guard(out io.Writer, run func() error) (err error) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+		ui.Close()
+		stack := debug.Stack()
+		if ui.game.Logger != nil {
+			ui.game.Logger.Error("panic", "value", fmt.Sprint(recovered), "build", engine.CurrentBuild().String(), "stack", string(stack))
+		}
+		fmt.Fprintf(out, "panic: %v\n%s\n\n%s\n", recovered, engine.CurrentBuild(), stack)
+		ui.simMu.Lock()
+		defer ui.simMu.Unlock()
+		fmt.Fprintln(out, ui.emergencySave())
+		err = fmt.Errorf("panic: %v", recovered)
+	}()
+	return run()
+}
+
+func (ui *UI) emergencySave() (message string) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			message = fmt.Sprintf("emergency save failed: %v", recovered)
+		}
+	}()
+	if ui.game.DevMode || ui.game.Replaying() || ui.coop != nil {
+		return "emergency save skipped"
+	}
+	path := ui.profile.SlotPath(emergencySlot)
+	if err := save.Write(ui.game, path); err != nil {
+		return fmt.Sprintf("emergency save failed: %v", err)
+	}
+	return fmt.Sprintf("emergency save written to %s (load it with :load %s)", path, emergencySlot)
+}
@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	cueOff        = "off"
+	cueBell       = "bell"
+	cueNotify     = "notify"
+	cueCycle      = "cycle"
+	cueInterval   = time.Second
+	maxCueMessage = 120
+)
+
+var cueStyles = []string{cueOff, cueBell, cueNotify}
+
+type pendingCue struct {
+	style   string
+	message string
+}
+
+func cueOption(kind string) option {
+	return option{
+		label: kind + " alert",
+		value: func(ui *UI) string { return ui.cueStyle(kind) },
+		change: func(ui *UI, delta int) {
+			ui.cycleCue(kind, delta)
+		},
+	}
+}
+
+func (ui *UI) cueStyle(kind string) string {
+	for _, style := range cueStyles {
+		if ui.settings.Cues[kind] == style {
+			return style
+		}
+	}
+	return cueOff
+}
+
+func (ui *UI) cycleCue(kind string, delta int) {
+	index := 0
+	for position, style := range cueStyles {
+		if style == ui.cueStyle(kind) {
+			index = position
+		}
+	}
+	next := cueStyles[((index+delta)%len(cueStyles)+len(cueStyles))%len(cueStyles)]
+	if ui.settings.Cues == nil {
+		ui.settings.Cues = make(map[string]string)
+	}
+	ui.settings.Cues[kind] = next
+	label := tr("%s alert: %s", kind, next)
+	ui.setStatus(ui.savedSetting(label))
+}
+
+func (ui *UI) cue(kind, message string, now time.Time) {
+	style := ui.cueStyle(kind)
+	if ui.screen == nil || style == cueOff {
+		return
+	}
+	if now.Sub(ui.cuedAt[kind]) < cueInterval {
+		return
+	}
+	if ui.cuedAt == nil {
+		ui.cuedAt = make(map[string]time.Time)
+	}
+	ui.cuedAt[kind] = now
+	ui.pendingCues = append(ui.pendingCues, pendingCue{style: style, message: message})
+	ui.dirty = true
+}
+
+func (ui *UI) takeCues() []pendingCue {
+	cues := ui.pendingCues
+	ui.pendingCues = nil
+	return cues
+}
+
+func (ui *UI) playCues(cues []pendingCue) {
+	for _, cue := range cues {
+		if cue.style == cueNotify && ui.notify(cue.message) {
+			continue
+		}
+		_ = ui.screen.Beep()
+	}
+}
+
+func (ui *UI) notify(message string) bool {
+	tty, ok := ui.screen.Tty()
+	if !ok {
+		return false
+	}
+	clean := strings.Map(func(r rune) rune {
+		if r < ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, message)
+	_, err := fmt.Fprintf(tty, "\x1b]9;%s\x07", truncate(clean, maxCueMessage))
+	return err == nil
+}
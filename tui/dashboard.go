@@ -0,0 +1,19 @@
+package tui
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed web
+var webAssets embed.FS
+
+func dashboardHandler() (http.Handler, error) {
+	assets, err := fs.Sub(webAssets, "web")
+	if err != nil {
+		return nil, fmt.Errorf("load dashboard: %w", err)
+	}
+	return http.FileServerFS(assets), nil
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+type detailLine struct {
+	text  string
+	style tcell.Style
+}
+
+func (ui *UI) drawDetails(x, y, width, height int) {
+	lines := ui.workerDetails()
+	for index, line := range lines {
+		if index >= height {
+			break
+		}
+		ui.drawText(x, y+index, truncate(line.text, width), line.style)
+	}
+}
+
+func (ui *UI) workerDetails() []detailLine {
+	industry := ui.game.Industries[ui.activeIndustry]
+	worker := industry.Workers[ui.selectedWorker]
+	definition := worker.Definition
+	plain := tcell.StyleDefault
+	heading := tcell.StyleDefault.Bold(true)
+
+	lines := []detailLine{
+		{text: definition.WorkerName, style: heading},
+		{text: tr("owned %s | tier %d", ui.formatNumber(worker.Owned), worker.Tier), style: plain},
+		{text: tr("produces %s", definition.Produces), style: plain},
+	}
+
+	perCycle := definition.ProdQuant * worker.Owned
+	lines = append(lines,
+		detailLine{text: tr("Production:"), style: heading},
+		detailLine{text: "  " + tr("%s x %s = %s per %s", ui.formatNumber(definition.ProdQuant), ui.formatNumber(worker.Owned), ui.formatNumber(perCycle), definition.ProdRate), style: plain},
+		detailLine{text: "  " + tr("%s/s while running", engine.TrimDecimals(float64(perCycle)/definition.ProdRate.Seconds())), style: plain},
+		detailLine{text: "  " + tr("lifetime cycles %s", ui.formatNumber(ui.game.Stats.Cycles[engine.WorkerStatsKey(industry.Key, definition.Key)])), style: plain},
+	)
+
+	lines = append(lines, detailLine{text: tr("Buy cost (each):"), style: heading})
+	lines = append(lines, ui.costLines(definition.Cost)...)
+	lines = append(lines, ui.affordLines(definition.Cost)...)
+
+	next := ui.game.UpgradeCost(ui.activeIndustry, ui.selectedWorker)
+	lines = append(lines, detailLine{text: tr("Upgrade to tier %d:", worker.Tier+1), style: heading})
+	lines = append(lines, ui.costLines(next)...)
+	lines = append(lines, ui.affordLines(next)...)
+
+	buyPayback, buyOK := ui.game.BuyPayback(ui.activeIndustry, ui.selectedWorker)
+	upgradePayback, upgradeOK := ui.game.UpgradePayback(ui.activeIndustry, ui.selectedWorker)
+	lines = append(lines,
+		detailLine{text: tr("Payback:"), style: heading},
+		detailLine{text: "  " + tr("buy %s | upgrade %s", engine.PaybackLabel(buyPayback, buyOK), engine.PaybackLabel(upgradePayback, upgradeOK)), style: plain},
+	)
+
+	lines = append(lines, detailLine{text: tr("Automation:"), style: heading})
+	switch {
+	case worker.Auto:
+		lines = append(lines, detailLine{text: "  " + tr("runs automatically"), style: plain})
+	case definition.AutoTier > 0:
+		lines = append(lines, detailLine{text: "  " + tr("unlocks at tier %d (%d to go)", definition.AutoTier, definition.AutoTier-worker.Tier), style: plain})
+	default:
+		lines = append(lines, detailLine{text: "  " + tr("manual only"), style: plain})
+	}
+	if worker.Owned == 0 {
+		lines = append(lines, detailLine{text: "  " + tr("own at least 1 to run"), style: plain})
+	}
+	return lines
+}
+
+func (ui *UI) affordLines(cost map[string]int) []detailLine {
+	if engine.CanAfford(cost, ui.game.Resources) {
+		return nil
+	}
+	wait, ok := ui.game.TimeToAfford(cost)
+	return []detailLine{{text: "  " + tr("affordable in %s", engine.AffordLabel(wait, ok)), style: ui.palette().locked}}
+}
+
+func (ui *UI) costLines(cost map[string]int) []detailLine {
+	if len(cost) == 0 {
+		return []detailLine{{text: "  " + tr("free"), style: tcell.StyleDefault}}
+	}
+	lines := make([]detailLine, 0, len(cost))
+	for _, resource := range engine.SortedKeys(cost) {
+		have := ui.game.Resources[resource]
+		marker := tr("ok")
+		style := ui.palette().good
+		if have < cost[resource] {
+			marker = tr("short")
+			style = ui.palette().bad
+		}
+		lines = append(lines, detailLine{
+			text:  "  " + tr("%s %s (have %s, %s)", resource, ui.formatNumber(cost[resource]), ui.formatNumber(have), marker),
+			style: style,
+		})
+	}
+	return lines
+}
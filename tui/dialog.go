@@ -0,0 +1,205 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+const defaultConfirmFraction = 0.8
+
+type confirmDialog struct {
+	title     string
+	lines     []string
+	hint      string
+	onConfirm func()
+	onCancel  func()
+}
+
+func (ui *UI) confirm(dialog confirmDialog) {
+	ui.dialog = dialog
+	ui.mode = modeConfirm
+}
+
+func (ui *UI) handleConfirmKey(event *tcell.EventKey) {
+	switch {
+	case event.Key() == tcell.KeyEnter, event.Key() == tcell.KeyRune && (event.Rune() == 'y' || event.Rune() == 'Y'):
+		ui.mode = modeMain
+		ui.dialog.onConfirm()
+	case event.Key() == tcell.KeyEscape, event.Key() == tcell.KeyRune && (event.Rune() == 'n' || event.Rune() == 'N'):
+		ui.mode = modeMain
+		if ui.dialog.onCancel != nil {
+			ui.dialog.onCancel()
+			return
+		}
+		ui.setStatus(engine.InfoStatus(tr("cancelled")))
+	}
+}
+
+func (ui *UI) dialogHint() string {
+	if ui.dialog.hint != "" {
+		return ui.dialog.hint
+	}
+	return tr("enter/y confirm | esc/n cancel")
+}
+
+func (ui *UI) needsConfirm(cost map[string]int, maxMode bool) bool {
+	if ui.game.DevMode || ui.settings.ConfirmFraction <= 0 {
+		return false
+	}
+	if maxMode {
+		return true
+	}
+	for resource, amount := range cost {
+		have := ui.game.Resources[resource]
+		if amount > 0 && float64(amount) > ui.settings.ConfirmFraction*float64(have) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ui *UI) buySelected() {
+	industryIndex, workerIndex := ui.activeIndustry, ui.selectedWorker
+	count, cost := ui.game.PlanBuy(industryIndex, workerIndex)
+	if count <= 0 || !ui.needsConfirm(cost, ui.game.BuyModeMax) {
+		ui.setStatus(ui.game.BuyWorker(industryIndex, workerIndex))
+		return
+	}
+	ui.confirmBuy(industryIndex, workerIndex, count, cost)
+}
+
+func (ui *UI) buyConfirmed(industryIndex, workerIndex, count int) (map[string]int, bool) {
+	cost := engine.MultiplyCost(ui.game.Industries[industryIndex].Workers[workerIndex].Definition.Cost, count)
+	if count <= 0 || count > engine.MaxQuantity || !engine.CanAfford(cost, ui.game.Resources) {
+		return cost, false
+	}
+	return cost, ui.needsConfirm(cost, false)
+}
+
+func (ui *UI) confirmBuy(industryIndex, workerIndex, count int, cost map[string]int) {
+	name := ui.game.Industries[industryIndex].Workers[workerIndex].Definition.WorkerName
+	ui.confirm(confirmDialog{
+		title: tr("Buy %s %s?", ui.formatNumber(count), name),
+		lines: ui.costSummary(cost),
+		onConfirm: func() {
+			ui.setStatus(ui.game.BuyCount(industryIndex, workerIndex, count))
+		},
+	})
+}
+
+func (ui *UI) buyMilestone() {
+	industryIndex, workerIndex := ui.activeIndustry, ui.selectedWorker
+	target, count, cost := ui.game.PlanMilestone(industryIndex, workerIndex)
+	name := ui.game.Industries[industryIndex].Workers[workerIndex].Definition.WorkerName
+	if !ui.game.DevMode && !engine.CanAfford(cost, ui.game.Resources) {
+		ui.setStatus(engine.ErrorStatus(tr("cannot afford %s more %s to reach %s", ui.formatNumber(count), name, ui.formatNumber(target))))
+		return
+	}
+	ui.confirm(confirmDialog{
+		title: tr("Buy %s %s to reach %s owned?", ui.formatNumber(count), name, ui.formatNumber(target)),
+		lines: ui.costSummary(cost),
+		onConfirm: func() {
+			ui.setStatus(ui.game.BuyCount(industryIndex, workerIndex, count))
+		},
+	})
+}
+
+func (ui *UI) upgradeSelected() {
+	industryIndex, workerIndex := ui.activeIndustry, ui.selectedWorker
+	cost := ui.game.UpgradeCost(industryIndex, workerIndex)
+	if !engine.CanAfford(cost, ui.game.Resources) || (ui.settings.SkipUpgradePreview && !ui.needsConfirm(cost, false)) {
+		ui.setStatus(ui.game.UpgradeWorker(industryIndex, workerIndex))
+		return
+	}
+	worker := ui.game.Industries[industryIndex].Workers[workerIndex]
+	ui.confirm(confirmDialog{
+		title: tr("Upgrade %s to tier %d?", worker.Definition.WorkerName, worker.Tier+1),
+		lines: ui.upgradePreview(worker, cost),
+		onConfirm: func() {
+			ui.setStatus(ui.game.UpgradeWorker(industryIndex, workerIndex))
+		},
+	})
+}
+
+func (ui *UI) upgradePreview(worker engine.WorkerState, cost map[string]int) []string {
+	definition := worker.Definition
+	after := worker
+	after.Tier++
+	after.Auto = worker.Auto || (definition.AutoTier > 0 && after.Tier >= definition.AutoTier)
+	lines := []string{
+		tr("tier: %d -> %d", worker.Tier, after.Tier),
+		tr("yield: %s %s per cycle (unchanged)", ui.formatNumber(definition.ProdQuant*worker.Owned), definition.Produces),
+		tr("rate: %s -> %s", ui.upgradeRate(worker), ui.upgradeRate(after)),
+		tr("auto: %s -> %s", autoSummary(worker), autoSummary(after)),
+		"cost:",
+	}
+	for _, line := range ui.costSummary(cost) {
+		lines = append(lines, "  "+line)
+	}
+	next := engine.ScaledCost(definition.Cost, definition.UpgradeMult, after.Tier)
+	return append(lines, tr("next upgrade: %s", ui.costText(next)))
+}
+
+func (ui *UI) costText(cost map[string]int) string {
+	parts := make([]string, 0, len(cost))
+	for _, resource := range engine.SortedKeys(cost) {
+		parts = append(parts, fmt.Sprintf("%s %s", resource, ui.formatNumber(cost[resource])))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (ui *UI) upgradeRate(worker engine.WorkerState) string {
+	perSecond := float64(worker.Definition.ProdQuant*worker.Owned) / worker.Definition.ProdRate.Seconds()
+	if !worker.Auto {
+		return tr("manual (%s/s while running)", engine.TrimDecimals(perSecond))
+	}
+	return tr("%s/s automatic", engine.TrimDecimals(perSecond))
+}
+
+func autoSummary(worker engine.WorkerState) string {
+	switch {
+	case worker.Auto:
+		return tr("yes")
+	case worker.Definition.AutoTier > 0:
+		return tr("no (tier %d)", worker.Definition.AutoTier)
+	}
+	return tr("no")
+}
+
+func (ui *UI) costSummary(cost map[string]int) []string {
+	lines := make([]string, 0, len(cost))
+	for _, resource := range engine.SortedKeys(cost) {
+		have := ui.game.Resources[resource]
+		lines = append(lines, tr("%s %s of %s (leaves %s)", resource, ui.formatNumber(cost[resource]), ui.formatNumber(have), ui.formatNumber(have-cost[resource])))
+	}
+	return lines
+}
+
+func (ui *UI) drawDialog(width, height int, title string, lines []string, hint string) {
+	boxWidth := textWidth(title)
+	for _, line := range lines {
+		boxWidth = engine.MaxInt(boxWidth, textWidth(line))
+	}
+	boxWidth = engine.MinInt(engine.MaxInt(boxWidth, textWidth(hint))+4, width-4)
+	boxHeight := engine.MinInt(len(lines)+5, height-2)
+	left := (width - boxWidth) / 2
+	top := (height - boxHeight) / 2
+	style := tcell.StyleDefault.Reverse(true)
+	for y := top; y < top+boxHeight; y++ {
+		for x := left; x < left+boxWidth; x++ {
+			ui.setCell(x, y, ' ', style)
+		}
+	}
+	ui.drawText(left+2, top+1, truncate(title, boxWidth-4), style.Bold(true))
+	for index, line := range lines {
+		if top+2+index >= top+boxHeight-2 {
+			break
+		}
+		ui.drawText(left+2, top+2+index, truncate(line, boxWidth-4), style)
+	}
+	ui.drawText(left+2, top+boxHeight-2, truncate(hint, boxWidth-4), style)
+}
@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+const (
+	maxLogEntries = 200
+)
+
+type logEntry struct {
+	At      time.Time
+	Kind    string
+	Message string
+}
+
+func (ui *UI) appendLog(at time.Time, kind, message string) {
+	if message == "" {
+		return
+	}
+	ui.logEntries = append(ui.logEntries, logEntry{At: at, Kind: kind, Message: message})
+	if len(ui.logEntries) > maxLogEntries {
+		ui.logEntries = ui.logEntries[len(ui.logEntries)-maxLogEntries:]
+	}
+	if ui.logScroll > 0 {
+		ui.logScroll = engine.MinInt(ui.logScroll+1, len(ui.logEntries)-1)
+	}
+	ui.mirrorLog(at, kind, message)
+}
+
+func (ui *UI) mirrorLog(at time.Time, kind, message string) {
+	if ui.EventLog == nil {
+		return
+	}
+	if _, err := fmt.Fprintf(ui.EventLog, "%s %-11s %s\n", at.Format(time.RFC3339), kind, message); err != nil {
+		ui.EventLog = nil
+		ui.setStatus(engine.ErrorStatus(tr("event log failed: %v", err)))
+	}
+}
+
+func OpenEventLog(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open event log: %w", err)
+	}
+	return file, nil
+}
+
+func (ui *UI) collectNotices() {
+	now := time.Now()
+	for _, notice := range ui.game.TakeNotices() {
+		ui.appendLog(notice.At, notice.Kind, notice.Message)
+		ui.cue(notice.Kind, notice.Message, now)
+		if toastWorthy(notice.Kind) {
+			ui.pushToast(notice.Kind, notice.Message, now)
+		}
+	}
+}
+
+func (ui *UI) toggleLog() {
+	ui.hideLog = !ui.hideLog
+	ui.logScroll = 0
+}
+
+func (ui *UI) scrollLog(delta int) {
+	if ui.hideLog {
+		return
+	}
+	ui.logScroll = clamp(ui.logScroll+delta, 0, engine.MaxInt(len(ui.logEntries)-1, 0))
+}
+
+func (ui *UI) drawLog(x, y, width, height int) {
+	title := tr("Log")
+	if ui.logScroll > 0 {
+		title = tr("Log (-%d)", ui.logScroll)
+	}
+	ui.drawText(x, y, truncate(title, width), tcell.StyleDefault.Bold(true))
+	rows := height - 1
+	end := len(ui.logEntries) - ui.logScroll
+	start := engine.MaxInt(end-rows, 0)
+	for index := start; index < end; index++ {
+		entry := ui.logEntries[index]
+		line := fmt.Sprintf("%s %s", entry.At.Format("15:04:05"), entry.Message)
+		ui.drawText(x, y+1+index-start, truncate(line, width), ui.logStyle(entry.Kind))
+	}
+}
+
+func (ui *UI) logStyle(kind string) tcell.Style {
+	switch kind {
+	case engine.NoticeUnlock, engine.NoticeAchievement:
+		return ui.palette().highlight
+	case engine.NoticeMilestone:
+		return ui.palette().accent
+	}
+	return ui.palette().base
+}
@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/config"
+	"archuser.org/go-game/engine"
+)
+
+type workerFilter int
+
+const (
+	filterAll workerFilter = iota
+	filterAffordable
+	filterRunning
+	filterAuto
+)
+
+var workerFilterLabels = map[workerFilter]string{
+	filterAll:        "all",
+	filterAffordable: "affordable",
+	filterRunning:    "running",
+	filterAuto:       "auto",
+}
+
+type workerSort int
+
+const (
+	sortConfig workerSort = iota
+	sortCost
+	sortYield
+	sortROI
+)
+
+var workerSortLabels = map[workerSort]string{
+	sortConfig: "config",
+	sortCost:   "cost",
+	sortYield:  "yield/s",
+	sortROI:    "roi",
+}
+
+func (ui *UI) visibleWorkers() []int {
+	workers := ui.game.Industries[ui.activeIndustry].Workers
+	query := strings.ToLower(ui.searchQuery)
+	visible := ui.visible[:0]
+	for index, worker := range workers {
+		if query != "" && !strings.Contains(strings.ToLower(worker.Definition.WorkerName), query) {
+			continue
+		}
+		if !ui.matchesFilter(worker) {
+			continue
+		}
+		visible = append(visible, index)
+	}
+	ui.sortWorkers(workers, visible)
+	ui.visible = visible
+	return visible
+}
+
+func (ui *UI) sortWorkers(workers []engine.WorkerState, visible []int) {
+	if ui.workerSort == sortConfig {
+		return
+	}
+	slices.SortStableFunc(visible, func(a, b int) int {
+		left, right := workers[a].Definition, workers[b].Definition
+		switch ui.workerSort {
+		case sortCost:
+			return cmp.Compare(totalCost(left.Cost), totalCost(right.Cost))
+		case sortYield:
+			return cmp.Compare(workerYield(right), workerYield(left))
+		}
+		return cmp.Compare(workerROI(right), workerROI(left))
+	})
+}
+
+func totalCost(cost map[string]int) int {
+	total := 0
+	for _, amount := range cost {
+		total += amount
+	}
+	return total
+}
+
+func workerYield(worker config.WorkerConfig) float64 {
+	if worker.ProdRate <= 0 {
+		return 0
+	}
+	return float64(worker.ProdQuant) / worker.ProdRate.Seconds()
+}
+
+func workerROI(worker config.WorkerConfig) float64 {
+	cost := totalCost(worker.Cost)
+	if cost <= 0 {
+		return workerYield(worker)
+	}
+	return workerYield(worker) / float64(cost)
+}
+
+func (ui *UI) matchesFilter(worker engine.WorkerState) bool {
+	switch ui.workerFilter {
+	case filterAffordable:
+		return ui.game.DevMode || engine.CanAfford(worker.Definition.Cost, ui.game.Resources)
+	case filterRunning:
+		return worker.Running
+	case filterAuto:
+		return worker.Auto
+	}
+	return true
+}
+
+func (ui *UI) selectedPosition(visible []int) (int, bool) {
+	for position, index := range visible {
+		if index == ui.selectedWorker {
+			return position, true
+		}
+	}
+	return 0, false
+}
+
+func (ui *UI) ensureSelectionVisible() []int {
+	visible := ui.visibleWorkers()
+	if _, ok := ui.selectedPosition(visible); !ok && len(visible) > 0 {
+		ui.selectedWorker = visible[0]
+	}
+	return visible
+}
+
+func (ui *UI) hasSelection() bool {
+	_, ok := ui.selectedPosition(ui.visibleWorkers())
+	return ok
+}
+
+func (ui *UI) cycleFilter() {
+	ui.workerFilter = (ui.workerFilter + 1) % workerFilter(len(workerFilterLabels))
+	ui.workerScroll = 0
+	ui.ensureSelectionVisible()
+	ui.setStatus(engine.InfoStatus(tr("filter: %s", workerFilterLabels[ui.workerFilter])))
+}
+
+func (ui *UI) cycleSort() {
+	ui.workerSort = (ui.workerSort + 1) % workerSort(len(workerSortLabels))
+	ui.workerScroll = 0
+	ui.setStatus(engine.InfoStatus(tr("sort: %s", workerSortLabels[ui.workerSort])))
+}
+
+func (ui *UI) openSearch() {
+	ui.mode = modeSearch
+	ui.workerScroll = 0
+}
+
+func (ui *UI) handleSearchKey(event *tcell.EventKey) {
+	switch event.Key() {
+	case tcell.KeyEnter:
+		ui.mode = modeMain
+	case tcell.KeyEscape:
+		ui.searchQuery = ""
+		ui.mode = modeMain
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if runes := []rune(ui.searchQuery); len(runes) > 0 {
+			ui.searchQuery = string(runes[:len(runes)-1])
+		}
+	case tcell.KeyRune:
+		ui.searchQuery += string(event.Rune())
+	}
+	ui.workerScroll = 0
+	ui.ensureSelectionVisible()
+}
+
+type titleKey struct {
+	name      string
+	searching bool
+	query     string
+	filter    workerFilter
+	sort      workerSort
+}
+
+func (ui *UI) workerListTitle(name string) string {
+	key := titleKey{name: name, searching: ui.mode == modeSearch, query: ui.searchQuery, filter: ui.workerFilter, sort: ui.workerSort}
+	return ui.texts.titles.get(key, key.text)
+}
+
+func (key titleKey) text() string {
+	title := tr("Workers - %s", key.name)
+	if key.searching {
+		title += fmt.Sprintf(" /%s_", key.query)
+	} else if key.query != "" {
+		title += fmt.Sprintf(" /%s", key.query)
+	}
+	if key.filter != filterAll {
+		title += fmt.Sprintf(" [%s]", workerFilterLabels[key.filter])
+	}
+	if key.sort != sortConfig {
+		title += fmt.Sprintf(" [sort: %s]", workerSortLabels[key.sort])
+	}
+	return title
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+const flashDuration = 700 * time.Millisecond
+
+type flash struct {
+	until time.Time
+	label string
+}
+
+func (ui *UI) collectCompletions(now time.Time) {
+	completions := ui.game.TakeCompletions()
+	for _, completion := range completions {
+		ui.markPending(completion)
+		if worker := ui.game.Industries[completion.Industry].Workers[completion.Worker]; !worker.Auto {
+			ui.cue(cueCycle, tr("%s cycle finished", worker.Definition.WorkerName), now)
+		}
+	}
+	if ui.settings.ReducedMotion {
+		return
+	}
+	if ui.flashes == nil {
+		ui.flashes = make(map[engine.WorkerRef]flash)
+	}
+	for _, completion := range completions {
+		ui.flashes[engine.WorkerRef{Industry: completion.Industry, Worker: completion.Worker}] = flash{
+			until: now.Add(flashDuration),
+			label: fmt.Sprintf("+%s %s", ui.formatNumber(completion.Amount), completion.Resource),
+		}
+	}
+}
+
+func (ui *UI) activeFlash(index int, now time.Time) (flash, bool) {
+	ref := engine.WorkerRef{Industry: ui.activeIndustry, Worker: index}
+	current, ok := ui.flashes[ref]
+	if !ok {
+		return flash{}, false
+	}
+	if now.After(current.until) {
+		delete(ui.flashes, ref)
+		return flash{}, false
+	}
+	return current, true
+}
+
+func (ui *UI) flashStyle() tcell.Style {
+	return ui.palette().highlight.Bold(true)
+}
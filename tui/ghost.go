@@ -0,0 +1,22 @@
+package tui
+
+import (
+	"archuser.org/go-game/engine"
+	"archuser.org/go-game/save"
+)
+
+func (ui *UI) consoleGhost(args []string) engine.Status {
+	if len(args) == 0 {
+		return engine.ErrorStatus(tr("usage: ghost <slot>"))
+	}
+	path, err := ui.slotPath(args)
+	if err != nil {
+		return engine.ErrorStatus(err.Error())
+	}
+	ghost, err := save.LoadGhost(path)
+	if err != nil {
+		return engine.ErrorStatus(tr("ghost failed: %v", err))
+	}
+	ui.game.UseGhost(ghost)
+	return engine.InfoStatus(tr("racing the ghost of %s", ghost.Name))
+}
@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+	"archuser.org/go-game/save"
+)
+
+type HarnessT interface {
+	Helper()
+	Fatalf(format string, args ...any)
+}
+
+type Harness struct {
+	UI     *UI
+	Screen tcell.SimulationScreen
+	Quit   bool
+}
+
+func NewHarness(game *engine.Engine, width, height int) (*Harness, error) {
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		return nil, fmt.Errorf("init simulation screen: %w", err)
+	}
+	screen.SetSize(width, height)
+	ui, err := NewUIWith(screen, game, save.Profile{Mode: save.ProfileNormal}, Settings{ConfirmFraction: defaultConfirmFraction})
+	if err != nil {
+		screen.Fini()
+		return nil, err
+	}
+	harness := &Harness{UI: ui, Screen: screen}
+	harness.Render()
+	return harness, nil
+}
+
+func NewHarnessFromConfig(path string, width, height int) (*Harness, error) {
+	game, err := engine.NewFromFile(path, engine.RealTime())
+	if err != nil {
+		return nil, fmt.Errorf("build game: %w", err)
+	}
+	return NewHarness(game, width, height)
+}
+
+func (h *Harness) Close() {
+	h.UI.Close()
+}
+
+func (h *Harness) Send(event tcell.Event) {
+	h.UI.withSim(func() {
+		if h.UI.handleEvent(event) {
+			h.Quit = true
+		}
+	})
+	h.Render()
+}
+
+func (h *Harness) Type(text string) {
+	for _, r := range text {
+		h.Send(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+}
+
+func (h *Harness) Press(key tcell.Key) {
+	h.Send(tcell.NewEventKey(key, 0, tcell.ModNone))
+}
+
+func (h *Harness) Keys(labels ...string) error {
+	for _, label := range labels {
+		key, err := parseKeyLabel(label)
+		if err != nil {
+			return err
+		}
+		if key >= ctrlKey('a') && key <= ctrlKey('z') {
+			h.Send(tcell.NewEventKey(tcell.Key(key), key, tcell.ModCtrl))
+			continue
+		}
+		h.Send(tcell.NewEventKey(tcell.KeyRune, key, tcell.ModNone))
+	}
+	return nil
+}
+
+func (h *Harness) Click(x, y int) {
+	h.Send(tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone))
+	h.Send(tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone))
+}
+
+func (h *Harness) Resize(width, height int) {
+	h.Screen.SetSize(width, height)
+	h.Send(tcell.NewEventResize(width, height))
+}
+
+func (h *Harness) Advance(elapsed time.Duration) {
+	h.UI.withSim(func() {
+		for elapsed > 0 {
+			step := min(elapsed, h.UI.tickInterval())
+			h.UI.game.Step(step)
+			now := h.UI.game.Now()
+			h.UI.collectCompletions(now)
+			h.UI.afterTick(now)
+			elapsed -= step
+		}
+	})
+	h.Render()
+}
+
+func (h *Harness) Render() {
+	h.UI.render()
+}
+
+func (h *Harness) Lines() []string {
+	cells, width, height := h.Screen.GetContents()
+	lines := make([]string, height)
+	for y := range height {
+		var line strings.Builder
+		for x := range width {
+			cell := cells[y*width+x]
+			if len(cell.Runes) == 0 {
+				line.WriteByte(' ')
+				continue
+			}
+			line.WriteString(string(cell.Runes))
+		}
+		lines[y] = line.String()
+	}
+	return lines
+}
+
+func (h *Harness) Line(y int) string {
+	lines := h.Lines()
+	if y < 0 || y >= len(lines) {
+		return ""
+	}
+	return lines[y]
+}
+
+func (h *Harness) Text() string {
+	return strings.Join(h.Lines(), "\n")
+}
+
+func (h *Harness) Find(text string) (int, int, bool) {
+	for y, line := range h.Lines() {
+		if index := strings.Index(line, text); index >= 0 {
+			return textWidth(line[:index]), y, true
+		}
+	}
+	return 0, 0, false
+}
+
+func (h *Harness) StyleAt(x, y int) tcell.Style {
+	_, style, _ := h.Screen.Get(x, y)
+	return style
+}
+
+func (h *Harness) ExpectText(t HarnessT, text string) {
+	t.Helper()
+	if _, _, ok := h.Find(text); !ok {
+		t.Fatalf("screen does not show %q:\n%s", text, h.Text())
+	}
+}
+
+func (h *Harness) ExpectNoText(t HarnessT, text string) {
+	t.Helper()
+	if _, _, ok := h.Find(text); ok {
+		t.Fatalf("screen unexpectedly shows %q:\n%s", text, h.Text())
+	}
+}
+
+func (h *Harness) ExpectLine(t HarnessT, y int, text string) {
+	t.Helper()
+	if !strings.Contains(h.Line(y), text) {
+		t.Fatalf("line %d is %q, want it to contain %q", y, h.Line(y), text)
+	}
+}
@@ -0,0 +1,66 @@
+package tui_test
+
+import (
+	"testing"
+	"time"
+
+	"archuser.org/go-game/engine"
+	"archuser.org/go-game/tui"
+)
+
+func newGame(t testing.TB, resources map[string]int) *engine.Engine {
+	t.Helper()
+	game, err := engine.NewFromFile("../config/game.yml", engine.Options{Start: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), Seed: 1})
+	if err != nil {
+		t.Fatalf("build game: %v", err)
+	}
+	for resource, amount := range resources {
+		game.Resources[resource] = amount
+	}
+	return game
+}
+
+func startHarness(t testing.TB, game *engine.Engine) *tui.Harness {
+	t.Helper()
+	harness, err := tui.NewHarness(game, 120, 40)
+	if err != nil {
+		t.Fatalf("start harness: %v", err)
+	}
+	t.Cleanup(harness.Close)
+	return harness
+}
+
+func newHarness(t *testing.T, resources map[string]int) *tui.Harness {
+	t.Helper()
+	return startHarness(t, newGame(t, resources))
+}
+
+func TestHarnessBuy(t *testing.T) {
+	h := newHarness(t, map[string]int{"coal": 100, "coins": 10})
+	if err := h.Keys("b"); err != nil {
+		t.Fatal(err)
+	}
+	h.Render()
+	h.ExpectText(t, "bought 1 Miner")
+	h.ExpectText(t, "Miner | owned 2")
+	h.ExpectText(t, "coal: 75")
+}
+
+func TestHarnessBuyUnaffordable(t *testing.T) {
+	h := newHarness(t, nil)
+	if err := h.Keys("b"); err != nil {
+		t.Fatal(err)
+	}
+	h.Render()
+	h.ExpectText(t, "cannot afford")
+	h.ExpectText(t, "Miner | owned 1")
+}
+
+func TestHarnessRun(t *testing.T) {
+	h := newHarness(t, nil)
+	if err := h.Keys("r"); err != nil {
+		t.Fatal(err)
+	}
+	h.Advance(time.Second)
+	h.ExpectText(t, "coal: 25")
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"archuser.org/go-game/save"
+)
+
+func (ui *UI) RunHeadless(in io.Reader, out io.Writer, stop <-chan os.Signal) error {
+	if ui.AutosaveEvery <= 0 {
+		ui.AutosaveEvery = defaultAutosaveInterval
+	}
+	tick := time.NewTicker(ui.tickInterval())
+	defer tick.Stop()
+	lines := readLines(in)
+
+	fmt.Fprintf(out, "Go Game - headless mode, autosave every %s. Type help for commands.\n", ui.AutosaveEvery)
+	printed := ui.lastStatusAt
+	for {
+		select {
+		case <-tick.C:
+			now := time.Now()
+			ui.step(now)
+			ui.game.TakeCompletions()
+			ui.afterTick(now)
+			ui.observeTick(now)
+			ui.printTimedNotices(out)
+			if ui.lastStatusAt.After(printed) {
+				printed = ui.lastStatusAt
+				fmt.Fprintf(out, "%s status %s\n", printed.Format(time.RFC3339), ui.statusMessage)
+			}
+			if ui.runEnded {
+				fmt.Fprintln(out, tr("Hardcore run ended: %s.", ui.profile.EndReason))
+				return nil
+			}
+		case call := <-ui.apiCalls:
+			ui.serveAPI(call)
+		case signal := <-stop:
+			fmt.Fprintf(out, "received %s, saving and exiting\n", signal)
+			return ui.saveOnExit()
+		case line, ok := <-lines:
+			if !ok {
+				lines = nil
+				continue
+			}
+			quit := ui.handlePlainLine(out, line)
+			printed = ui.lastStatusAt
+			if quit {
+				return ui.saveOnExit()
+			}
+		}
+	}
+}
+
+func readLines(in io.Reader) <-chan string {
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+	return lines
+}
+
+func (ui *UI) saveOnExit() error {
+	if ui.game.DevMode || ui.runEnded {
+		return nil
+	}
+	if err := save.Write(ui.game, ui.profile.SavePath()); err != nil {
+		return fmt.Errorf("save on exit: %w", err)
+	}
+	return nil
+}
+
+func (ui *UI) printTimedNotices(out io.Writer) {
+	for _, notice := range ui.game.TakeNotices() {
+		ui.mirrorLog(notice.At, notice.Kind, notice.Message)
+		fmt.Fprintf(out, "%s %s %s\n", notice.At.Format(time.RFC3339), notice.Kind, notice.Message)
+	}
+}
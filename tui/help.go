@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+type uiMode int
+
+const (
+	modeMain uiMode = iota
+	modeHelp
+	modeKeymap
+	modeConfirm
+	modeSearch
+	modeHistory
+	modeChart
+	modeQuantity
+	modeAchievements
+	modeStats
+	modeOptions
+	modeMenu
+	modeIndustryStats
+	modePlugins
+	modeConsole
+	modeLeaderboard
+)
+
+var helpConcepts = []string{
+	"Industries hold a ladder of workers. Running a worker starts a cycle that",
+	"yields its resource, or more of the worker it produces.",
+	"Upgrades raise a worker's tier; at its auto tier it runs on its own.",
+	"Buy mode 1x buys one worker; 100% spends all you can on the selection.",
+	"Rows marked * are affordable; locked rows need one owned worker to run.",
+	"Tabs marked $ have something affordable; ! means a manual cycle finished there.",
+	"The underlined row is the best buy or upgrade by payback time at resource values.",
+}
+
+func (ui *UI) drawHelp(width, height int) {
+	ui.drawText(2, 1, tr("Help - press any key to return"), tcell.StyleDefault.Bold(true))
+	entries := make([]string, 0, len(actionOrder)+4)
+	for _, act := range actionOrder {
+		entries = append(entries, fmt.Sprintf("%-9s %s", ui.keys.labels(act), tr(actionDescriptions[act])))
+	}
+	entries = append(entries,
+		fmt.Sprintf("%-9s %s", "←/→", tr("previous / next industry")),
+		fmt.Sprintf("%-9s %s", "↑/↓", tr("previous / next worker")),
+		fmt.Sprintf("%-9s %s", "1-9 / 0", tr("select worker row / last row")),
+		fmt.Sprintf("%-9s %s", "F1-F5", tr("jump to industry (also alt+1-5)")),
+		fmt.Sprintf("%-9s %s", "PgUp/PgDn", tr("scroll event log")),
+		fmt.Sprintf("%-9s %s", "mouse", tr("click tabs, rows, footer")),
+		fmt.Sprintf("%-9s %s", "enter", tr("dismiss a sticky status message")),
+		fmt.Sprintf("%-9s %s", "esc", tr("pause menu")),
+		fmt.Sprintf("%-9s %s", "ctrl+c", tr("quit immediately")),
+	)
+
+	ui.drawText(2, 3, tr("Keys:"), tcell.StyleDefault.Bold(true))
+	concepts := helpConcepts
+	rows := height - 7 - len(concepts) - 2
+	if ui.compact || rows <= 0 || (len(entries)+rows-1)/rows > 2 {
+		concepts = nil
+		rows = height - 7
+	}
+	rows = engine.MaxInt(rows, 1)
+	columns := engine.MaxInt((len(entries)+rows-1)/rows, 1)
+	columnWidth := (width - 4) / columns
+	for index, entry := range entries {
+		ui.drawText(4+(index/rows)*columnWidth, 4+index%rows, truncate(entry, columnWidth-2), tcell.StyleDefault)
+	}
+
+	if len(concepts) > 0 {
+		y := 4 + engine.MinInt(rows, len(entries)) + 1
+		ui.drawText(2, y, tr("Concepts:"), tcell.StyleDefault.Bold(true))
+		for index, line := range concepts {
+			ui.drawText(4, y+1+index, truncate(tr(line), width-6), tcell.StyleDefault)
+		}
+	}
+	ui.drawText(2, height-2, truncate(tr("Current %s", ui.buyModeLabel()), width-4), ui.palette().good)
+}
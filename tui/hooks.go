@@ -0,0 +1,19 @@
+package tui
+
+import (
+	"archuser.org/go-game/engine"
+)
+
+func (ui *UI) observeGame() {
+	ui.ratesStale = true
+	ui.game.OnWorkerStateChanged(func(engine.WorkerStateChanged) { ui.ratesStale = true })
+	ui.game.OnEvent(ui.markDirty)
+}
+
+func (ui *UI) currentRates() map[string]float64 {
+	if ui.ratesStale || ui.rates == nil {
+		ui.rates = ui.game.Rates()
+		ui.ratesStale = false
+	}
+	return ui.rates
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"github.com/rivo/uniseg"
+
+	"archuser.org/go-game/config"
+	"archuser.org/go-game/engine"
+)
+
+func (ui *UI) icon(icon config.IconConfig) string {
+	if icon.Glyph != "" && ui.canDisplay(icon.Glyph) {
+		return icon.Glyph
+	}
+	return icon.ASCII
+}
+
+func (ui *UI) canDisplay(text string) bool {
+	if ui.screen == nil || uniseg.StringWidth(text) > 2 {
+		return false
+	}
+	if displayable, ok := ui.displayable[text]; ok {
+		return displayable
+	}
+	if ui.displayable == nil {
+		ui.displayable = make(map[string]bool)
+	}
+	displayable := true
+	for _, char := range text {
+		if !ui.screen.CanDisplay(char, false) {
+			displayable = false
+			break
+		}
+	}
+	ui.displayable[text] = displayable
+	return displayable
+}
+
+type labelKey struct {
+	prefix string
+	label  string
+}
+
+func (ui *UI) withIcon(icon config.IconConfig, label string) string {
+	if prefix := ui.icon(icon); prefix != "" {
+		return ui.labels.get(labelKey{prefix: prefix, label: label}, func() string { return prefix + " " + label })
+	}
+	return label
+}
+
+func (ui *UI) resourceLabel(resource string) string {
+	return ui.withIcon(ui.game.Icons[resource], resource)
+}
+
+func (ui *UI) workerLabel(worker engine.WorkerState) string {
+	return ui.withIcon(worker.Definition.Icon, worker.Definition.WorkerName)
+}
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+func (ui *UI) openIndustryStats() {
+	ui.statsScroll = 0
+	ui.mode = modeIndustryStats
+}
+
+func (ui *UI) handleIndustryStatsKey(event *tcell.EventKey, rows int) {
+	switch event.Key() {
+	case tcell.KeyLeft:
+		ui.shiftIndustry(-1)
+		ui.statsScroll = 0
+	case tcell.KeyRight:
+		ui.shiftIndustry(1)
+		ui.statsScroll = 0
+	default:
+		ui.handleStatsKey(event, rows)
+	}
+}
+
+func (ui *UI) drawIndustryStats(width, height int) {
+	industry := ui.game.Industries[ui.activeIndustry]
+	title := tr("Industry - %s (%d of %d)", industry.Name, ui.activeIndustry+1, len(ui.game.Industries))
+	ui.drawScrollLines(width, height, title, tr("←/→ industry | ↑/↓ PgUp/PgDn scroll | any other key returns"), ui.industryLines(industry))
+}
+
+func (ui *UI) industryLines(industry engine.IndustryState) []detailLine {
+	stats := ui.game.Stats
+	plain := ui.palette().base
+	heading := plain.Bold(true)
+	output := make(map[string]int)
+	top, topValue := "", 0.0
+	utilization := []detailLine{{text: tr("Utilization:"), style: heading}}
+	for _, worker := range industry.Workers {
+		key := engine.WorkerStatsKey(industry.Key, worker.Definition.Key)
+		produced := stats.Output[key]
+		output[ui.producedLabel(industry, worker.Definition.Produces)] += produced
+		if value := float64(produced) * ui.game.UnitValue(industry, worker.Definition.Produces); value > topValue {
+			top, topValue = worker.Definition.WorkerName, value
+		}
+		utilization = append(utilization, detailLine{text: "  " + utilizationLabel(worker.Definition.WorkerName, stats.Busy[key], stats.Employed[key]), style: plain})
+	}
+	lines := append([]detailLine{{text: tr("Output:"), style: heading}}, ui.amountLines(output, plain)...)
+	lines = append(lines, detailLine{text: tr("Top producer:"), style: heading})
+	if top == "" {
+		lines = append(lines, detailLine{text: "  " + tr("none yet"), style: plain})
+	} else {
+		lines = append(lines, detailLine{text: "  " + tr("%s (worth %s)", top, ui.formatNumber(int(topValue))), style: plain})
+	}
+	lines = append(lines, utilization...)
+	lines = append(lines, detailLine{text: tr("Spend:"), style: heading})
+	return append(lines, ui.amountLines(stats.Invested[industry.Key], plain)...)
+}
+
+func (ui *UI) producedLabel(industry engine.IndustryState, produces string) string {
+	if target, ok := engine.FindWorkerIndex(industry.Workers, produces); ok {
+		return industry.Workers[target].Definition.WorkerName
+	}
+	return produces
+}
+
+func (ui *UI) amountLines(amounts map[string]int, style tcell.Style) []detailLine {
+	var lines []detailLine
+	for _, key := range engine.SortedKeys(amounts) {
+		if amounts[key] > 0 {
+			lines = append(lines, detailLine{text: fmt.Sprintf("  %s %s", key, ui.formatNumber(amounts[key])), style: style})
+		}
+	}
+	if len(lines) == 0 {
+		return []detailLine{{text: "  " + tr("none yet"), style: style}}
+	}
+	return lines
+}
+
+func utilizationLabel(name string, busy, employed time.Duration) string {
+	if employed <= 0 {
+		return tr("%s: not yet employed", name)
+	}
+	percent := int(100 * busy / employed)
+	return tr("%s: %d%% running (%s busy, %s idle)", name, percent, busy.Truncate(time.Second), (employed - busy).Truncate(time.Second))
+}
@@ -0,0 +1,323 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+type action string
+
+const ctrlPrefix = "ctrl+"
+
+const (
+	actionIndustryPrev action = "industry-prev"
+	actionIndustryNext action = "industry-next"
+	actionWorkerPrev   action = "worker-prev"
+	actionWorkerNext   action = "worker-next"
+	actionBuy          action = "buy"
+	actionRun          action = "run"
+	actionRunLowest    action = "run-lowest"
+	actionUpgrade      action = "upgrade"
+	actionBuyMode      action = "buy-mode"
+	actionSave         action = "save"
+	actionLoad         action = "load"
+	actionExport       action = "export"
+	actionHelp         action = "help"
+	actionKeymap       action = "keymap"
+	actionNotation     action = "notation"
+	actionLog          action = "log"
+	actionDetails      action = "details"
+	actionFirstWorker  action = "first-worker"
+	actionLastWorker   action = "last-worker"
+	actionSearch       action = "search"
+	actionFilter       action = "filter"
+	actionSort         action = "sort"
+	actionHistory      action = "status-history"
+	actionPalette      action = "palette"
+	actionChart        action = "chart"
+	actionPause        action = "pause"
+	actionBuyQuantity  action = "buy-quantity"
+	actionAchievements action = "achievements"
+	actionStats        action = "stats"
+	actionSlower       action = "slower"
+	actionFaster       action = "faster"
+	actionBuyMilestone action = "buy-milestone"
+	actionLayout       action = "layout"
+	actionOptions      action = "options"
+	actionIndustry     action = "industry-stats"
+	actionPlugins      action = "plugins"
+	actionLeaderboard  action = "leaderboard"
+	actionUndo         action = "undo"
+	actionRedo         action = "redo"
+	actionConsole      action = "console"
+	actionSuspend      action = "suspend"
+)
+
+var actionOrder = []action{
+	actionIndustryPrev,
+	actionIndustryNext,
+	actionWorkerPrev,
+	actionWorkerNext,
+	actionFirstWorker,
+	actionLastWorker,
+	actionSearch,
+	actionConsole,
+	actionFilter,
+	actionSort,
+	actionBuy,
+	actionBuyQuantity,
+	actionBuyMilestone,
+	actionRun,
+	actionRunLowest,
+	actionUpgrade,
+	actionUndo,
+	actionRedo,
+	actionBuyMode,
+	actionPause,
+	actionSlower,
+	actionFaster,
+	actionSave,
+	actionLoad,
+	actionExport,
+	actionHelp,
+	actionKeymap,
+	actionNotation,
+	actionOptions,
+	actionPalette,
+	actionLayout,
+	actionLog,
+	actionHistory,
+	actionChart,
+	actionAchievements,
+	actionStats,
+	actionIndustry,
+	actionPlugins,
+	actionLeaderboard,
+	actionDetails,
+	actionSuspend,
+}
+
+var actionDescriptions = map[action]string{
+	actionIndustryPrev: "previous industry",
+	actionIndustryNext: "next industry",
+	actionWorkerPrev:   "previous worker",
+	actionWorkerNext:   "next worker",
+	actionFirstWorker:  "first worker (press twice)",
+	actionLastWorker:   "last worker",
+	actionSearch:       "search workers by name",
+	actionConsole:      "command console (:buy miner 10, tab completes)",
+	actionFilter:       "cycle filter: all/affordable/running/auto",
+	actionSort:         "cycle sort: config/cost/yield/roi",
+	actionHistory:      "recent status messages",
+	actionPalette:      "cycle color palette",
+	actionLayout:       "cycle layout: classic/wide/log-focused",
+	actionOptions:      "quick options menu",
+	actionChart:        "resource history chart",
+	actionPause:        "pause / resume simulation",
+	actionBuyQuantity:  "buy a chosen quantity",
+	actionBuyMilestone: "buy up to the next owned milestone",
+	actionAchievements: "achievements",
+	actionStats:        "statistics",
+	actionIndustry:     "industry summary",
+	actionPlugins:      "plugin panels",
+	actionLeaderboard:  "online leaderboard (s submits this run)",
+	actionSlower:       "slow simulation down",
+	actionFaster:       "speed simulation up",
+	actionBuy:          "buy workers",
+	actionRun:          "run selected worker",
+	actionRunLowest:    "run lowest idle manual worker",
+	actionUpgrade:      "upgrade selected worker",
+	actionUndo:         "undo last purchase or upgrade",
+	actionRedo:         "redo undone purchase or upgrade",
+	actionBuyMode:      "toggle buy mode",
+	actionSave:         "save game",
+	actionLoad:         "load game",
+	actionExport:       "export stats",
+	actionHelp:         "show this help",
+	actionKeymap:       "remap keys",
+	actionNotation:     "toggle scientific notation",
+	actionLog:          "toggle event log (PgUp/PgDn scroll)",
+	actionDetails:      "toggle worker details",
+	actionSuspend:      "suspend to the shell (resume with fg)",
+}
+
+type keymap struct {
+	keys   map[action][]rune
+	lookup map[rune]action
+}
+
+func defaultKeymap() *keymap {
+	k := &keymap{keys: map[action][]rune{
+		actionIndustryPrev: {'a', 'h'},
+		actionIndustryNext: {'d', 'l'},
+		actionWorkerPrev:   {'w', 'k'},
+		actionWorkerNext:   {'s', 'j'},
+		actionFirstWorker:  {'g'},
+		actionLastWorker:   {'G'},
+		actionSearch:       {'/'},
+		actionConsole:      {':'},
+		actionFilter:       {'f'},
+		actionSort:         {'O'},
+		actionHistory:      {'H'},
+		actionPalette:      {'c'},
+		actionLayout:       {'V'},
+		actionOptions:      {'o'},
+		actionChart:        {'C'},
+		actionPause:        {'p'},
+		actionBuyQuantity:  {'B'},
+		actionBuyMilestone: {'M'},
+		actionAchievements: {'A'},
+		actionStats:        {'S'},
+		actionIndustry:     {'I'},
+		actionPlugins:      {'P'},
+		actionLeaderboard:  {'R'},
+		actionSlower:       {'-'},
+		actionFaster:       {'+', '='},
+		actionBuy:          {'b'},
+		actionRun:          {'r', ' '},
+		actionRunLowest:    {'q'},
+		actionUpgrade:      {'u'},
+		actionUndo:         {'z'},
+		actionRedo:         {ctrlKey('y')},
+		actionBuyMode:      {'m'},
+		actionSave:         {'t'},
+		actionLoad:         {'y'},
+		actionExport:       {'e'},
+		actionHelp:         {'?'},
+		actionKeymap:       {'K'},
+		actionNotation:     {'n'},
+		actionLog:          {'L'},
+		actionDetails:      {'i'},
+		actionSuspend:      {ctrlKey('z')},
+	}}
+	k.rebuild()
+	return k
+}
+
+func (k *keymap) rebuild() {
+	k.lookup = make(map[rune]action)
+	for _, act := range actionOrder {
+		for _, key := range k.keys[act] {
+			k.lookup[key] = act
+		}
+	}
+}
+
+func (k *keymap) apply(bindings map[string][]string) error {
+	for name, labels := range bindings {
+		act := action(name)
+		if _, ok := actionDescriptions[act]; !ok {
+			return fmt.Errorf("unknown action %q", name)
+		}
+		keys := make([]rune, 0, len(labels))
+		for _, label := range labels {
+			key, err := parseKeyLabel(label)
+			if err != nil {
+				return fmt.Errorf("action %s: %w", name, err)
+			}
+			keys = append(keys, key)
+		}
+		k.keys[act] = keys
+	}
+	k.rebuild()
+	return nil
+}
+
+func (k *keymap) export() map[string][]string {
+	bindings := make(map[string][]string, len(k.keys))
+	for _, act := range actionOrder {
+		labels := make([]string, 0, len(k.keys[act]))
+		for _, key := range k.keys[act] {
+			labels = append(labels, keyLabel(key))
+		}
+		bindings[string(act)] = labels
+	}
+	return bindings
+}
+
+func (k *keymap) bind(act action, key rune) (action, bool) {
+	if owner, ok := k.lookup[key]; ok && owner != act {
+		return owner, false
+	}
+	k.keys[act] = []rune{key}
+	k.rebuild()
+	return act, true
+}
+
+func (k *keymap) reset(act action) (action, bool) {
+	defaults := defaultKeymap().keys[act]
+	for _, key := range defaults {
+		if owner, ok := k.lookup[key]; ok && owner != act {
+			return owner, false
+		}
+	}
+	k.keys[act] = defaults
+	k.rebuild()
+	return act, true
+}
+
+func (k *keymap) action(key rune) (action, bool) {
+	act, ok := k.lookup[key]
+	return act, ok
+}
+
+func (k *keymap) primary(act action) rune {
+	keys := k.keys[act]
+	if len(keys) == 0 {
+		return 0
+	}
+	return keys[0]
+}
+
+func (k *keymap) label(act action) string {
+	return keyLabel(k.primary(act))
+}
+
+func (k *keymap) labels(act action) string {
+	keys := k.keys[act]
+	labels := make([]string, 0, len(keys))
+	for _, key := range keys {
+		labels = append(labels, keyLabel(key))
+	}
+	return strings.Join(labels, "/")
+}
+
+func ctrlKey(letter rune) rune {
+	return letter - 'a' + 1
+}
+
+func keyRune(event *tcell.EventKey) rune {
+	if event.Key() >= tcell.KeyCtrlA && event.Key() <= tcell.KeyCtrlZ {
+		return ctrlKey(rune(event.Key()-tcell.KeyCtrlA) + 'a')
+	}
+	return event.Rune()
+}
+
+func parseKeyLabel(label string) (rune, error) {
+	if label == "space" {
+		return ' ', nil
+	}
+	if letter, ok := strings.CutPrefix(label, ctrlPrefix); ok && len(letter) == 1 && letter[0] >= 'a' && letter[0] <= 'z' {
+		return ctrlKey(rune(letter[0])), nil
+	}
+	runes := []rune(label)
+	if len(runes) != 1 {
+		return 0, fmt.Errorf("invalid key %q", label)
+	}
+	return runes[0], nil
+}
+
+func keyLabel(key rune) string {
+	switch key {
+	case 0:
+		return "unbound"
+	case ' ':
+		return "space"
+	}
+	if key >= ctrlKey('a') && key <= ctrlKey('z') {
+		return ctrlPrefix + string(key-ctrlKey('a')+'a')
+	}
+	return string(key)
+}
@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+const (
+	sidebarMinWidth  = 28
+	sidebarMaxWidth  = 44
+	logMinHeight     = 4
+	deltaWindow      = 10
+	bodyTop          = 4
+	layoutClassic    = "classic"
+	layoutWide       = "wide"
+	layoutLogFocused = "log-focused"
+)
+
+type rect struct {
+	x, y, width, height int
+}
+
+type mainLayout struct {
+	workers rect
+	sidebar rect
+	log     rect
+	divider rect
+}
+
+var layoutPresets = []string{layoutClassic, layoutWide, layoutLogFocused}
+
+func layoutMain(width, height int, showLog bool, preset string) mainLayout {
+	bodyHeight := height - bodyTop - 4
+	sidebarWidth := clamp(width*3/10, sidebarMinWidth, sidebarMaxWidth)
+	if preset == layoutWide {
+		sidebarWidth = width / 2
+	}
+	leftWidth := width - sidebarWidth - 1
+	layout := mainLayout{
+		workers: rect{x: 0, y: bodyTop, width: leftWidth, height: bodyHeight},
+		sidebar: rect{x: leftWidth + 1, y: bodyTop, width: sidebarWidth - 2, height: bodyHeight},
+		divider: rect{x: leftWidth, y: bodyTop, width: 1, height: bodyHeight},
+	}
+	if !showLog {
+		return layout
+	}
+	switch preset {
+	case layoutWide:
+		logHeight := engine.MaxInt(bodyHeight/2, logMinHeight)
+		layout.sidebar.height = bodyHeight - logHeight - 1
+		layout.log = rect{x: leftWidth + 2, y: bodyTop + layout.sidebar.height + 1, width: sidebarWidth - 3, height: logHeight}
+	case layoutLogFocused:
+		workersHeight := engine.MaxInt(bodyHeight/3, logMinHeight)
+		layout.workers.height = workersHeight
+		layout.log = rect{x: 2, y: bodyTop + workersHeight + 1, width: leftWidth - 4, height: bodyHeight - workersHeight - 1}
+	default:
+		logHeight := engine.MaxInt(bodyHeight/3, logMinHeight)
+		layout.workers.height = bodyHeight - logHeight - 1
+		layout.log = rect{x: 2, y: bodyTop + layout.workers.height + 1, width: leftWidth - 4, height: logHeight}
+	}
+	return layout
+}
+
+func (ui *UI) layoutPreset() string {
+	for _, preset := range layoutPresets {
+		if preset == ui.settings.Layout {
+			return preset
+		}
+	}
+	return layoutClassic
+}
+
+func (ui *UI) cycleLayout() {
+	next := layoutPresets[0]
+	for index, preset := range layoutPresets {
+		if preset == ui.layoutPreset() {
+			next = layoutPresets[(index+1)%len(layoutPresets)]
+		}
+	}
+	ui.settings.Layout = next
+	label := tr("layout: %s", next)
+	ui.setStatus(ui.savedSetting(label))
+}
+
+func (ui *UI) drawMain(width, height int) {
+	ui.drawHeader(width)
+	layout := layoutMain(width, height, !ui.hideLog, ui.layoutPreset())
+	ui.drawWorkers(layout.workers.x+2, layout.workers.y, layout.workers.width-2, layout.workers.height)
+	for y := layout.divider.y; y < layout.divider.y+layout.divider.height; y++ {
+		ui.setCell(layout.divider.x, y, '│', tcell.StyleDefault.Dim(true))
+	}
+	ui.drawSidebar(layout.sidebar)
+	if layout.log.height > 0 {
+		ui.drawLog(layout.log.x, layout.log.y, layout.log.width, layout.log.height)
+	}
+	ui.drawFooter(2, height-2, width)
+}
+
+func (ui *UI) drawSidebar(area rect) {
+	used := ui.drawResources(area.x+1, area.y, area.width-1, area.height)
+	if !ui.showDetails || used+1 >= area.height {
+		return
+	}
+	ui.drawDetails(area.x+1, area.y+used+1, area.width-1, area.height-used-1)
+}
+
+func (ui *UI) drawResources(x, y, width, height int) int {
+	ui.drawText(x, y, tr("Resources:"), tcell.StyleDefault.Bold(true))
+	rates := ui.currentRates()
+	row := 1
+	for _, resource := range ui.sortedResources() {
+		if row+1 >= height {
+			break
+		}
+		rate := ui.formatRate(rates[resource])
+		amount := amountKey{label: ui.resourceLabel(resource), amount: ui.game.Resources[resource], scientific: ui.settings.Scientific}
+		line := truncate(ui.texts.amounts.get(amount, amount.text), width-textWidth(rate)-1)
+		ui.drawText(x, y+row, line, tcell.StyleDefault)
+		ui.drawText(x+width-textWidth(rate), y+row, rate, ui.rateStyle(rates[resource]))
+		delta, style := ui.resourceDelta(resource)
+		sparkWidth := width - textWidth(delta) - 1
+		ui.sparkline = engine.AppendSparkline(ui.sparkline[:0], ui.game.History.Values(resource, sparkWidth), sparkWidth)
+		for offset, char := range ui.sparkline {
+			ui.setCell(x+offset, y+row+1, char, ui.palette().accent)
+		}
+		ui.drawText(x+width-textWidth(delta), y+row+1, delta, style)
+		row += 2
+	}
+	return row
+}
+
+func (ui *UI) sortedResources() []string {
+	ui.resourceOrder = ui.resourceOrder[:0]
+	for resource := range ui.game.Resources {
+		ui.resourceOrder = append(ui.resourceOrder, resource)
+	}
+	slices.Sort(ui.resourceOrder)
+	return ui.resourceOrder
+}
+
+type amountKey struct {
+	label      string
+	amount     int
+	scientific bool
+}
+
+func (key amountKey) text() string {
+	return fmt.Sprintf("%s: %s", key.label, engine.FormatNumber(key.amount, key.scientific))
+}
+
+type deltaKey struct {
+	delta      int
+	scientific bool
+}
+
+func (ui *UI) resourceDelta(resource string) (string, tcell.Style) {
+	values := ui.game.History.Values(resource, deltaWindow+1)
+	if len(values) < 2 {
+		return "", ui.palette().base
+	}
+	key := deltaKey{delta: values[len(values)-1] - values[0], scientific: ui.settings.Scientific}
+	switch {
+	case key.delta > 0:
+		return ui.texts.deltas.get(key, key.text), ui.palette().good
+	case key.delta < 0:
+		return ui.texts.deltas.get(key, key.text), ui.palette().bad
+	}
+	return "", ui.palette().base
+}
+
+func (key deltaKey) text() string {
+	if key.delta < 0 {
+		return fmt.Sprintf("▼ -%s", engine.FormatNumber(-key.delta, key.scientific))
+	}
+	return fmt.Sprintf("▲ +%s", engine.FormatNumber(key.delta, key.scientific))
+}
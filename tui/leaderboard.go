@@ -0,0 +1,288 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+const (
+	leaderboardKeyFile   = "leaderboard.key"
+	leaderboardTimeout   = 10 * time.Second
+	leaderboardKeyHeader = "X-Go-Game-Key"
+	leaderboardSigHeader = "X-Go-Game-Signature"
+	defaultBoard         = "default"
+)
+
+type LeaderboardSettings struct {
+	URL    string `yaml:"url,omitempty"`
+	Player string `yaml:"player,omitempty"`
+}
+
+type leaderboardEntry struct {
+	Player       string                 `json:"player"`
+	Board        string                 `json:"board"`
+	Hardcore     bool                   `json:"hardcore"`
+	NetWorth     int                    `json:"netWorth"`
+	Playtime     time.Duration          `json:"playtimeNs"`
+	Earned       map[string]int         `json:"earned"`
+	Milestones   []leaderboardMilestone `json:"milestones"`
+	Achievements int                    `json:"achievements"`
+	SubmittedAt  time.Time              `json:"submittedAt"`
+}
+
+type leaderboardMilestone struct {
+	Resource string        `json:"resource"`
+	Amount   int           `json:"amount"`
+	After    time.Duration `json:"afterNs"`
+}
+
+type leaderboardRank struct {
+	Rank       int           `json:"rank"`
+	Player     string        `json:"player"`
+	NetWorth   int           `json:"netWorth"`
+	Playtime   time.Duration `json:"playtimeNs"`
+	Milestones int           `json:"milestones"`
+}
+
+type leaderboardView struct {
+	ranks   []leaderboardRank
+	message engine.Status
+}
+
+func (ui *UI) openLeaderboard() {
+	ui.statsScroll = 0
+	ui.mode = modeLeaderboard
+	ui.refreshLeaderboard()
+}
+
+func (ui *UI) handleLeaderboardKey(event *tcell.EventKey, rows int) {
+	switch {
+	case event.Key() == tcell.KeyRune && event.Rune() == 'r':
+		ui.refreshLeaderboard()
+	case event.Key() == tcell.KeyRune && event.Rune() == 's':
+		ui.setStatus(ui.submitLeaderboard())
+	default:
+		ui.handleStatsKey(event, rows)
+	}
+}
+
+func (ui *UI) drawLeaderboard(width, height int) {
+	ui.drawScrollLines(width, height, tr("Leaderboard"), tr("r refresh | s submit this run | ↑/↓ scroll | any other key returns"), ui.leaderboardLines())
+}
+
+func (ui *UI) leaderboardLines() []detailLine {
+	plain := ui.palette().base
+	if message := ui.leaderboard.message; message.Message != "" {
+		return []detailLine{{text: message.Message, style: ui.classStyle(message.Class)}}
+	}
+	if len(ui.leaderboard.ranks) == 0 {
+		return []detailLine{{text: tr("no entries yet"), style: plain}}
+	}
+	lines := []detailLine{{text: fmt.Sprintf("%4s  %-20s %12s %10s %10s", "#", tr("Player"), tr("Net worth"), tr("Milestones"), tr("Played")), style: plain.Bold(true)}}
+	player := ui.leaderboardPlayer()
+	for _, rank := range ui.leaderboard.ranks {
+		style := plain
+		if rank.Player == player {
+			style = ui.palette().good
+		}
+		lines = append(lines, detailLine{text: fmt.Sprintf("%4d  %-20s %12s %10d %10s", rank.Rank, truncate(rank.Player, 20),
+			ui.formatNumber(rank.NetWorth), rank.Milestones, rank.Playtime.Truncate(time.Second)), style: style})
+	}
+	return lines
+}
+
+func (ui *UI) refreshLeaderboard() {
+	server := ui.settings.Leaderboard.URL
+	if server == "" {
+		ui.leaderboard = leaderboardView{message: engine.ErrorStatus(tr("no leaderboard configured (set leaderboard.url in settings)"))}
+		return
+	}
+	ui.leaderboard.message = engine.InfoStatus(tr("loading rankings..."))
+	board := ui.leaderboardBoard()
+	ui.background(func() func(ui *UI) {
+		ranks, err := fetchRankings(server, board)
+		return func(ui *UI) {
+			if err != nil {
+				ui.leaderboard = leaderboardView{message: engine.ErrorStatus(tr("leaderboard failed: %v", err))}
+				return
+			}
+			ui.leaderboard = leaderboardView{ranks: ranks}
+		}
+	})
+}
+
+func (ui *UI) submitLeaderboard() engine.Status {
+	server := ui.settings.Leaderboard.URL
+	if server == "" {
+		return engine.ErrorStatus(tr("no leaderboard configured (set leaderboard.url in settings)"))
+	}
+	if ui.game.Replaying() {
+		return engine.ErrorStatus(tr("replay playback is read-only"))
+	}
+	return ui.guardDevMode("submit", func() engine.Status {
+		key, err := loadLeaderboardKey(ui.profile.Dir())
+		if err != nil {
+			return engine.ErrorStatus(tr("leaderboard submit failed: %v", err))
+		}
+		entry := ui.leaderboardEntry(time.Now())
+		ui.background(func() func(ui *UI) {
+			err := submitEntry(server, key, entry)
+			return func(ui *UI) {
+				if err != nil {
+					ui.setStatus(engine.ErrorStatus(tr("leaderboard submit failed: %v", err)))
+					return
+				}
+				ui.setStatus(engine.SuccessStatus(tr("submitted to the leaderboard")))
+				if ui.mode == modeLeaderboard {
+					ui.refreshLeaderboard()
+				}
+			}
+		})
+		return engine.InfoStatus(tr("submitting to the leaderboard..."))
+	})
+}
+
+func (ui *UI) leaderboardEntry(now time.Time) leaderboardEntry {
+	stats := ui.game.Stats
+	entry := leaderboardEntry{
+		Player:       ui.leaderboardPlayer(),
+		Board:        ui.leaderboardBoard(),
+		Hardcore:     ui.profile.Hardcore(),
+		NetWorth:     ui.game.NetWorth(),
+		Playtime:     stats.Playtime,
+		Earned:       engine.CopyResources(stats.Earned),
+		Achievements: len(stats.Achievements),
+		SubmittedAt:  now,
+	}
+	for _, milestone := range stats.Milestones {
+		entry.Milestones = append(entry.Milestones, leaderboardMilestone{Resource: milestone.Resource, Amount: milestone.Amount, After: milestone.At.Sub(stats.StartedAt)})
+	}
+	return entry
+}
+
+func (ui *UI) leaderboardPlayer() string {
+	if player := ui.settings.Leaderboard.Player; player != "" {
+		return player
+	}
+	if ui.profile.Name != "" {
+		return ui.profile.Name
+	}
+	return defaultPlayer
+}
+
+func (ui *UI) leaderboardBoard() string {
+	payload, err := os.ReadFile(ui.ConfigPath)
+	if err != nil {
+		return defaultBoard
+	}
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:6])
+}
+
+func (ui *UI) background(work func() func(ui *UI)) {
+	if ui.apiCalls == nil {
+		ui.apiCalls = make(chan apiCall)
+	}
+	go func() {
+		apply := work()
+		ctx, cancel := context.WithTimeout(context.Background(), leaderboardTimeout)
+		defer cancel()
+		ui.callAPI(ctx, func(ui *UI) (int, any) {
+			apply(ui)
+			return 0, nil
+		})
+	}()
+}
+
+func loadLeaderboardKey(dir string) (ed25519.PrivateKey, error) {
+	path := filepath.Join(dir, leaderboardKeyFile)
+	payload, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return generateLeaderboardKey(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read leaderboard key: %w", err)
+	}
+	seed, err := hex.DecodeString(strings.TrimSpace(string(payload)))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("parse leaderboard key %s", path)
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+func generateLeaderboardKey(path string) (ed25519.PrivateKey, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate leaderboard key: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(key.Seed())+"\n"), 0o600); err != nil {
+		return nil, fmt.Errorf("write leaderboard key: %w", err)
+	}
+	return key, nil
+}
+
+func submitEntry(server string, key ed25519.PrivateKey, entry leaderboardEntry) error {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("serialize entry: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), leaderboardTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(server, "/")+"/submit", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(leaderboardKeyHeader, base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)))
+	request.Header.Set(leaderboardSigHeader, base64.StdEncoding.EncodeToString(ed25519.Sign(key, body)))
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("submit entry: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("submit entry: %s", response.Status)
+	}
+	return nil
+}
+
+func fetchRankings(server, board string) ([]leaderboardRank, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), leaderboardTimeout)
+	defer cancel()
+	target := strings.TrimRight(server, "/") + "/rankings?board=" + url.QueryEscape(board)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("fetch rankings: %w", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("fetch rankings: %s", response.Status)
+	}
+	var ranks []leaderboardRank
+	if err := json.NewDecoder(response.Body).Decode(&ranks); err != nil {
+		return nil, fmt.Errorf("parse rankings: %w", err)
+	}
+	return ranks, nil
+}
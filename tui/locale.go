@@ -0,0 +1,9 @@
+package tui
+
+import (
+	"archuser.org/go-game/locale"
+)
+
+func tr(text string, args ...any) string {
+	return locale.Tr(text, args...)
+}
@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+func (ui *UI) logStatus(message string) {
+	if ui.game.Logger == nil {
+		return
+	}
+	if ui.statusClass == engine.StatusError {
+		ui.game.Logger.Error("status", "message", message)
+		return
+	}
+	ui.game.Logger.Info("status", "message", message)
+}
+
+func (ui *UI) observeTick(started time.Time) {
+	ui.metrics.tick(time.Since(started))
+	if ui.game.Logger == nil || started.Sub(ui.perfLoggedAt) < engine.PerfLogInterval {
+		return
+	}
+	ui.perfLoggedAt = started
+	ui.game.Logger.Info("performance",
+		"ticks", ui.metrics.Ticks,
+		"tick_avg", engine.AverageDuration(ui.metrics.TickTotal, ui.metrics.Ticks),
+		"tick_last", ui.metrics.LastTick,
+		"frames", ui.metrics.Frames,
+		"frame_avg", engine.AverageDuration(ui.metrics.FrameTotal, ui.metrics.Frames),
+		"frame_last", ui.metrics.LastFrame,
+		"revision", ui.game.Revision(),
+	)
+}
@@ -0,0 +1,238 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+	"archuser.org/go-game/save"
+)
+
+type menuItem struct {
+	label   string
+	enabled bool
+	choose  func() bool
+}
+
+type menuPage struct {
+	title string
+	items []menuItem
+	index int
+	back  bool
+}
+
+func (ui *UI) OpenMenu() {
+	ui.showMenu(ui.mainMenu())
+}
+
+func (ui *UI) openPauseMenu() {
+	ui.showMenu(newMenuPage(tr("Paused"), false, []menuItem{
+		{label: tr("Resume"), enabled: true, choose: func() bool { ui.closeMenu(); return false }},
+		{label: tr("Save"), enabled: !ui.game.DevMode, choose: func() bool { return ui.menuAction(actionSave) }},
+		{label: tr("Load"), enabled: !ui.game.DevMode && !ui.profile.Hardcore(), choose: func() bool { return ui.menuAction(actionLoad) }},
+		{label: tr("Settings"), enabled: true, choose: func() bool { ui.openOptions(modeMenu); return false }},
+		{label: tr("Quit"), enabled: true, choose: func() bool { return true }},
+	}))
+}
+
+func (ui *UI) showMenu(page menuPage) {
+	if ui.mode != modeMenu {
+		ui.menuPaused = ui.clock.paused
+	}
+	ui.clock.paused = true
+	ui.menu = page
+	ui.mode = modeMenu
+}
+
+func (ui *UI) closeMenu() {
+	ui.clock.paused = ui.menuPaused
+	ui.mode = modeMain
+}
+
+func (ui *UI) menuAction(act action) bool {
+	ui.closeMenu()
+	ui.perform(act)
+	return false
+}
+
+func (ui *UI) mainMenu() menuPage {
+	_, err := os.Stat(ui.profile.SavePath())
+	hasSave := err == nil
+	restart := !ui.profile.Hardcore() || !hasSave
+	slots, scenarios := ui.slotItems(), ui.scenarioItems()
+	return newMenuPage(tr("Main Menu"), false, []menuItem{
+		{label: tr("Continue"), enabled: hasSave, choose: ui.menuContinue},
+		{label: tr("New Game"), enabled: restart, choose: func() bool { return ui.menuNewGame(ui.ConfigPath) }},
+		{label: tr("Load Slot"), enabled: restart && len(slots) > 0, choose: func() bool { return ui.openSubmenu(tr("Load Slot"), slots) }},
+		{label: tr("Scenarios"), enabled: restart && len(scenarios) > 0, choose: func() bool { return ui.openSubmenu(tr("Scenarios"), scenarios) }},
+		{label: tr("Settings"), enabled: true, choose: func() bool { ui.openOptions(modeMenu); return false }},
+		{label: tr("Quit"), enabled: true, choose: func() bool { return true }},
+	})
+}
+
+func newMenuPage(title string, back bool, items []menuItem) menuPage {
+	page := menuPage{title: title, items: items, back: back}
+	for index, item := range items {
+		if item.enabled {
+			page.index = index
+			break
+		}
+	}
+	return page
+}
+
+func (ui *UI) openSubmenu(title string, items []menuItem) bool {
+	ui.menu = newMenuPage(title, true, items)
+	return false
+}
+
+func (ui *UI) slotItems() []menuItem {
+	var items []menuItem
+	slots, _ := ui.profile.Slots()
+	for _, slot := range slots {
+		path := ui.profile.SlotPath(slot)
+		items = append(items, menuItem{label: slot, enabled: true, choose: func() bool { return ui.menuLoadSlot(path) }})
+	}
+	savePath := ui.profile.SavePath()
+	names, err := save.BackupNames(savePath)
+	if err != nil {
+		return items
+	}
+	for index := len(names) - 1; index >= 0; index-- {
+		name := names[index]
+		label := name
+		if at, err := save.BackupTime(savePath, name); err == nil {
+			label = at.Format("2006-01-02 15:04:05")
+		}
+		path := filepath.Join(filepath.Dir(savePath), save.BackupDir, name)
+		items = append(items, menuItem{label: label, enabled: true, choose: func() bool { return ui.menuLoadSlot(path) }})
+	}
+	return items
+}
+
+func (ui *UI) scenarioItems() []menuItem {
+	if ui.ConfigPath == "" {
+		return nil
+	}
+	paths, err := filepath.Glob(filepath.Join(filepath.Dir(ui.ConfigPath), "*.yml"))
+	if err != nil {
+		return nil
+	}
+	items := make([]menuItem, 0, len(paths))
+	for _, path := range paths {
+		scenario := path
+		label := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		items = append(items, menuItem{label: label, enabled: true, choose: func() bool { return ui.menuNewGame(scenario) }})
+	}
+	return items
+}
+
+func (ui *UI) menuContinue() bool {
+	status := ui.loadGame()
+	ui.setStatus(status)
+	if status.Class == engine.StatusError {
+		return false
+	}
+	ui.closeMenu()
+	now := time.Now()
+	ui.showOffline(ui.game.ApplyOffline(now))
+	if ui.profile.Hardcore() && ui.game.Bankrupt() {
+		ui.endRun("bankrupt", now)
+	}
+	return false
+}
+
+func (ui *UI) menuNewGame(path string) bool {
+	fresh, err := engine.NewFromFile(path, engine.RealTime())
+	if err != nil {
+		ui.setStatus(engine.ErrorStatus(tr("new game failed: %v", err)))
+		return false
+	}
+	fresh.DevMode = ui.game.DevMode
+	if ui.SeedSet {
+		fresh.UseSeed(ui.Seed)
+	}
+	recording := ui.game.Recording()
+	ui.game.ReplaceWith(fresh)
+	if recording {
+		if err := ui.game.StartRecording(); err != nil {
+			ui.setStatus(engine.ErrorStatus(tr("recording failed: %v", err)))
+		}
+	}
+	ui.clock = newSimClock(ui.game.Now())
+	ui.syncedAt, ui.syncedRevision = time.Time{}, 0
+	ui.resetView()
+	ui.setStatus(engine.InfoStatus(tr("new game: %s", strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))))
+	ui.closeMenu()
+	return false
+}
+
+func (ui *UI) menuLoadSlot(path string) bool {
+	if err := save.LoadBackup(ui.game, path, ui.profile.SavePath()); err != nil {
+		ui.setStatus(engine.ErrorStatus(tr("load failed: %v", err)))
+		return false
+	}
+	ui.resetView()
+	ui.markSynced(time.Now())
+	ui.setStatus(engine.SuccessStatus(tr("loaded %s", filepath.Base(path))))
+	ui.closeMenu()
+	return false
+}
+
+func (ui *UI) handleMenuKey(event *tcell.EventKey) bool {
+	page := &ui.menu
+	switch event.Key() {
+	case tcell.KeyUp:
+		page.move(-1)
+	case tcell.KeyDown:
+		page.move(1)
+	case tcell.KeyEnter:
+		if item := page.items[page.index]; item.enabled {
+			return item.choose()
+		}
+	case tcell.KeyEscape:
+		if page.back {
+			ui.menu = ui.mainMenu()
+			return false
+		}
+		ui.closeMenu()
+	}
+	return false
+}
+
+func (p *menuPage) move(delta int) {
+	for step := 1; step <= len(p.items); step++ {
+		index := (p.index + delta*step + len(p.items)*step) % len(p.items)
+		if p.items[index].enabled {
+			p.index = index
+			return
+		}
+	}
+}
+
+func (ui *UI) drawMenu(width, height int) {
+	page := ui.menu
+	top := engine.MaxInt((height-len(page.items))/2-3, 0)
+	ui.drawTextCentered(width, top, tr("Go Game - Industry Ladder"), tcell.StyleDefault.Bold(true))
+	ui.drawTextCentered(width, top+1, page.title, ui.palette().accent)
+	for index, item := range page.items {
+		style := ui.palette().base
+		label := "  " + item.label + "  "
+		switch {
+		case !item.enabled:
+			style = ui.palette().locked
+		case index == page.index:
+			style = style.Reverse(true)
+			label = "> " + item.label + " <"
+		}
+		ui.drawTextCentered(width, top+3+index, label, style)
+	}
+	if ui.statusClass == engine.StatusError && ui.statusMessage != "" {
+		ui.drawTextCentered(width, height-3, ui.statusMessage, ui.statusStyle())
+	}
+	ui.drawTextCentered(width, height-2, tr("↑/↓ select | enter choose | esc back"), ui.palette().good)
+}
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+const metricsTimeout = 2 * time.Second
+
+type loopMetrics struct {
+	Ticks      int                `json:"ticks"`
+	TickTotal  time.Duration      `json:"tickTotalNs"`
+	LastTick   time.Duration      `json:"lastTickNs"`
+	Frames     int                `json:"frames"`
+	FrameTotal time.Duration      `json:"frameTotalNs"`
+	LastFrame  time.Duration      `json:"lastFrameNs"`
+	Revision   int                `json:"revision"`
+	NetWorth   int                `json:"netWorth"`
+	Resources  map[string]int     `json:"resources"`
+	Rates      map[string]float64 `json:"rates"`
+}
+
+func (m *loopMetrics) tick(elapsed time.Duration) {
+	m.Ticks++
+	m.TickTotal += elapsed
+	m.LastTick = elapsed
+}
+
+func (m *loopMetrics) frame(elapsed time.Duration) {
+	m.Frames++
+	m.FrameTotal += elapsed
+	m.LastFrame = elapsed
+}
+
+func (ui *UI) StartMetrics(addr string) (*http.Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listen metrics: %w", err)
+	}
+	if ui.apiCalls == nil {
+		ui.apiCalls = make(chan apiCall)
+	}
+	expvar.Publish("game", expvar.Func(func() any {
+		snapshot, _ := ui.metricsSnapshot(context.Background())
+		return snapshot
+	}))
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", ui.prometheusHandler)
+	mux.Handle("GET /debug/vars", expvar.Handler())
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	return server, nil
+}
+
+func (ui *UI) metricsSnapshot(ctx context.Context) (loopMetrics, bool) {
+	ctx, cancel := context.WithTimeout(ctx, metricsTimeout)
+	defer cancel()
+	reply, ok := ui.callAPI(ctx, func(ui *UI) (int, any) {
+		snapshot := ui.metrics
+		snapshot.Revision = ui.game.Revision()
+		snapshot.NetWorth = ui.game.NetWorth()
+		snapshot.Resources = engine.CopyResources(ui.game.Resources)
+		snapshot.Rates = ui.game.Rates()
+		return http.StatusOK, snapshot
+	})
+	if !ok {
+		return loopMetrics{}, false
+	}
+	return reply.body.(loopMetrics), true
+}
+
+func (ui *UI) prometheusHandler(w http.ResponseWriter, r *http.Request) {
+	snapshot, ok := ui.metricsSnapshot(r.Context())
+	if !ok {
+		http.Error(w, "game loop busy", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	writePrometheus(w, snapshot)
+}
+
+func writePrometheus(w io.Writer, m loopMetrics) {
+	fmt.Fprintln(w, "# TYPE go_game_resource gauge")
+	for _, resource := range engine.SortedKeys(m.Resources) {
+		fmt.Fprintf(w, "go_game_resource{resource=%q} %d\n", resource, m.Resources[resource])
+	}
+	fmt.Fprintln(w, "# TYPE go_game_rate_per_second gauge")
+	for _, resource := range engine.SortedKeys(m.Rates) {
+		fmt.Fprintf(w, "go_game_rate_per_second{resource=%q} %g\n", resource, m.Rates[resource])
+	}
+	fmt.Fprintln(w, "# TYPE go_game_net_worth gauge")
+	fmt.Fprintf(w, "go_game_net_worth %d\n", m.NetWorth)
+	fmt.Fprintln(w, "# TYPE go_game_revision counter")
+	fmt.Fprintf(w, "go_game_revision %d\n", m.Revision)
+	fmt.Fprintln(w, "# TYPE go_game_ticks_total counter")
+	fmt.Fprintf(w, "go_game_ticks_total %d\n", m.Ticks)
+	fmt.Fprintln(w, "# TYPE go_game_tick_seconds_total counter")
+	fmt.Fprintf(w, "go_game_tick_seconds_total %g\n", m.TickTotal.Seconds())
+	fmt.Fprintln(w, "# TYPE go_game_tick_duration_seconds gauge")
+	fmt.Fprintf(w, "go_game_tick_duration_seconds %g\n", m.LastTick.Seconds())
+	fmt.Fprintln(w, "# TYPE go_game_frames_total counter")
+	fmt.Fprintf(w, "go_game_frames_total %d\n", m.Frames)
+	fmt.Fprintln(w, "# TYPE go_game_frame_seconds_total counter")
+	fmt.Fprintf(w, "go_game_frame_seconds_total %g\n", m.FrameTotal.Seconds())
+	fmt.Fprintln(w, "# TYPE go_game_frame_duration_seconds gauge")
+	fmt.Fprintf(w, "go_game_frame_duration_seconds %g\n", m.LastFrame.Seconds())
+}
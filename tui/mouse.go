@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+type regionPick int
+
+const (
+	pickNone regionPick = iota
+	pickIndustry
+	pickWorker
+)
+
+type hitRegion struct {
+	x, y, width int
+	action      func()
+	perform     action
+	pick        regionPick
+	index       int
+}
+
+type footerItem struct {
+	label  string
+	action action
+}
+
+func (ui *UI) footerAction(act action, label string) footerItem {
+	return footerItem{label: fmt.Sprintf("%s %s", ui.keys.label(act), tr(label)), action: act}
+}
+
+func (ui *UI) addRegion(x, y, width int, action func()) {
+	if width <= 0 {
+		return
+	}
+	ui.regions = append(ui.regions, hitRegion{x: x, y: y, width: width, action: action})
+}
+
+func (ui *UI) addActionRegion(x, y, width int, act action) {
+	if width <= 0 {
+		return
+	}
+	ui.regions = append(ui.regions, hitRegion{x: x, y: y, width: width, perform: act})
+}
+
+func (ui *UI) addPickRegion(x, y, width int, pick regionPick, index int) {
+	if width <= 0 {
+		return
+	}
+	ui.regions = append(ui.regions, hitRegion{x: x, y: y, width: width, pick: pick, index: index})
+}
+
+func (ui *UI) handleMouse(event *tcell.EventMouse) {
+	if ui.runEnded {
+		return
+	}
+	if ui.mode == modeHelp {
+		if event.Buttons()&tcell.Button1 != 0 {
+			ui.mode = modeMain
+		}
+		return
+	}
+	if ui.mode != modeMain {
+		return
+	}
+	buttons := event.Buttons()
+	switch {
+	case buttons&tcell.WheelUp != 0:
+		ui.shiftWorker(-1)
+	case buttons&tcell.WheelDown != 0:
+		ui.shiftWorker(1)
+	case buttons&tcell.Button1 != 0:
+		if ui.mouseDown {
+			return
+		}
+		ui.mouseDown = true
+		x, y := event.Position()
+		ui.clickAt(x, y)
+	default:
+		ui.mouseDown = false
+	}
+}
+
+func (ui *UI) clickAt(x, y int) {
+	for index := len(ui.regions) - 1; index >= 0; index-- {
+		region := ui.regions[index]
+		if y == region.y && x >= region.x && x < region.x+region.width {
+			switch {
+			case region.pick == pickIndustry:
+				ui.selectIndustry(region.index)
+			case region.pick == pickWorker:
+				ui.selectedWorker = region.index
+			case region.action == nil:
+				ui.perform(region.perform)
+			default:
+				region.action()
+			}
+			return
+		}
+	}
+}
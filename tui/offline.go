@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+func (ui *UI) showOffline(report engine.OfflineReport) {
+	if report.Elapsed < engine.MinOfflineDuration {
+		return
+	}
+	lines := []string{tr("away for %s", report.Elapsed.Truncate(time.Second))}
+	if report.Credited < report.Elapsed {
+		lines = append(lines, tr("earnings capped at %s", report.Credited))
+	}
+	for _, earning := range report.Earnings {
+		parts := make([]string, 0, len(earning.Amounts))
+		for _, key := range engine.SortedKeys(earning.Amounts) {
+			parts = append(parts, fmt.Sprintf("%s +%s", key, ui.formatNumber(earning.Amounts[key])))
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", tr(earning.Source), strings.Join(parts, ", ")))
+	}
+	if len(report.Earnings) == 0 {
+		lines = append(lines, tr("nothing was produced while you were away"))
+	}
+	for _, resource := range engine.SortedKeys(report.Upkeep) {
+		if due := report.Upkeep[resource]; due > 0 {
+			lines = append(lines, tr("upkeep: %s -%s", resource, ui.formatNumber(due)))
+		}
+	}
+	done := func() { ui.setStatus(engine.SuccessStatus(tr("welcome back"))) }
+	ui.confirm(confirmDialog{title: tr("Offline earnings"), lines: lines, hint: tr("enter/esc continue"), onConfirm: done, onCancel: done})
+}
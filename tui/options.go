@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+const defaultAutosaveInterval = time.Minute
+
+type option struct {
+	label  string
+	value  func(ui *UI) string
+	change func(ui *UI, delta int)
+}
+
+var quickOptions = []option{
+	{label: "autosave", value: (*UI).autosaveLabel, change: func(ui *UI, _ int) { ui.toggleAutosave() }},
+	{label: "buy mode", value: (*UI).buyModeValue, change: func(ui *UI, _ int) { ui.perform(actionBuyMode) }},
+	{label: "speed", value: func(ui *UI) string { return fmt.Sprintf("%sx", engine.TrimDecimals(ui.clock.speed())) }, change: (*UI).shiftSpeed},
+	{label: "paused", value: func(ui *UI) string { return onOff(ui.clock.paused) }, change: func(ui *UI, _ int) { ui.togglePause() }},
+	{label: "theme", value: func(ui *UI) string { return ui.palette().name }, change: func(ui *UI, _ int) { ui.cyclePalette() }},
+	{label: "layout", value: (*UI).layoutPreset, change: func(ui *UI, _ int) { ui.cycleLayout() }},
+	cueOption(engine.NoticeUnlock),
+	cueOption(engine.NoticeMilestone),
+	cueOption(engine.NoticeAchievement),
+	cueOption(cueCycle),
+}
+
+func onOff(value bool) string {
+	if value {
+		return tr("on")
+	}
+	return tr("off")
+}
+
+func (ui *UI) openOptions(parent uiMode) {
+	ui.optionIndex = 0
+	ui.optionsParent = parent
+	ui.mode = modeOptions
+}
+
+func (ui *UI) handleOptionsKey(event *tcell.EventKey) {
+	switch {
+	case event.Key() == tcell.KeyUp:
+		ui.optionIndex = (ui.optionIndex + len(quickOptions) - 1) % len(quickOptions)
+	case event.Key() == tcell.KeyDown:
+		ui.optionIndex = (ui.optionIndex + 1) % len(quickOptions)
+	case event.Key() == tcell.KeyLeft:
+		quickOptions[ui.optionIndex].change(ui, -1)
+	case event.Key() == tcell.KeyRight, event.Key() == tcell.KeyEnter, event.Key() == tcell.KeyRune && event.Rune() == ' ':
+		quickOptions[ui.optionIndex].change(ui, 1)
+	case event.Key() == tcell.KeyEscape, event.Key() == tcell.KeyRune && ui.keys.lookup[event.Rune()] == actionOptions:
+		ui.mode = ui.optionsParent
+	}
+}
+
+func (ui *UI) buyModeValue() string {
+	if ui.game.BuyModeMax {
+		return "100%"
+	}
+	return "1x"
+}
+
+func (ui *UI) autosaveLabel() string {
+	if ui.AutosaveEvery <= 0 {
+		return tr("off")
+	}
+	return tr("every %s", ui.AutosaveEvery)
+}
+
+func (ui *UI) toggleAutosave() {
+	if ui.profile.Hardcore() {
+		ui.setStatus(engine.ErrorStatus(tr("autosave is required in hardcore mode")))
+		return
+	}
+	if ui.AutosaveEvery > 0 {
+		ui.autosaveSaved, ui.AutosaveEvery = ui.AutosaveEvery, 0
+	} else {
+		ui.AutosaveEvery = ui.autosaveSaved
+		if ui.AutosaveEvery <= 0 {
+			ui.AutosaveEvery = defaultAutosaveInterval
+		}
+		ui.lastSavedAt = time.Now()
+	}
+	ui.setStatus(engine.InfoStatus(tr("autosave: %s", ui.autosaveLabel())))
+}
+
+func (ui *UI) optionLines() []string {
+	lines := make([]string, 0, len(quickOptions))
+	for index, item := range quickOptions {
+		marker := "  "
+		if index == ui.optionIndex {
+			marker = "> "
+		}
+		lines = append(lines, fmt.Sprintf("%s%-17s %s", marker, tr(item.label), item.value(ui)))
+	}
+	return lines
+}
+
+func (ui *UI) drawOptions(width, height int) {
+	ui.drawDialog(width, height, tr("Options"), ui.optionLines(), tr("↑/↓ select | ←/→ enter change | esc close"))
+}
@@ -0,0 +1,68 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+const overlayInterval = time.Second
+
+type overlayStats struct {
+	UpdatedAt time.Time          `json:"updatedAt"`
+	Date      string             `json:"date"`
+	NetWorth  int                `json:"netWorth"`
+	Resources map[string]int     `json:"resources"`
+	Rates     map[string]float64 `json:"rates"`
+	Lines     []string           `json:"lines"`
+}
+
+func (ui *UI) writeOverlay(now time.Time) {
+	if ui.Overlay == "" || now.Sub(ui.overlayAt) < overlayInterval {
+		return
+	}
+	ui.overlayAt = now
+	if err := writeOverlayFile(ui.Overlay, ui.overlayStats(now)); err != nil {
+		ui.Overlay = ""
+		ui.setStatus(engine.ErrorStatus(tr("overlay failed: %v", err)))
+	}
+}
+
+func (ui *UI) overlayStats(now time.Time) overlayStats {
+	stats := overlayStats{
+		UpdatedAt: now,
+		Date:      ui.game.Date().String(),
+		NetWorth:  ui.game.NetWorth(),
+		Resources: engine.CopyResources(ui.game.Resources),
+		Rates:     ui.game.Rates(),
+	}
+	for _, resource := range engine.SortedKeys(stats.Resources) {
+		stats.Lines = append(stats.Lines, fmt.Sprintf("%s %s (%s)", resource, ui.formatNumber(stats.Resources[resource]), ui.formatRate(stats.Rates[resource])))
+	}
+	stats.Lines = append(stats.Lines, tr("Net worth %s", ui.formatNumber(stats.NetWorth)), stats.Date)
+	return stats
+}
+
+func writeOverlayFile(path string, stats overlayStats) error {
+	payload := []byte(strings.Join(stats.Lines, "\n") + "\n")
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		encoded, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return fmt.Errorf("serialize overlay: %w", err)
+		}
+		payload = encoded
+	}
+	temp := path + ".tmp"
+	if err := os.WriteFile(temp, payload, 0o644); err != nil {
+		return fmt.Errorf("write overlay: %w", err)
+	}
+	if err := os.Rename(temp, path); err != nil {
+		return fmt.Errorf("replace overlay: %w", err)
+	}
+	return nil
+}
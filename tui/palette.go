@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"os"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+type palette struct {
+	name      string
+	base      tcell.Style
+	good      tcell.Style
+	bad       tcell.Style
+	accent    tcell.Style
+	highlight tcell.Style
+	locked    tcell.Style
+}
+
+var palettes = []palette{
+	{
+		name:      "default",
+		base:      tcell.StyleDefault,
+		good:      tcell.StyleDefault.Foreground(tcell.ColorGreen),
+		bad:       tcell.StyleDefault.Foreground(tcell.ColorRed),
+		accent:    tcell.StyleDefault.Foreground(tcell.ColorTeal),
+		highlight: tcell.StyleDefault.Foreground(tcell.ColorYellow),
+		locked:    tcell.StyleDefault.Dim(true),
+	},
+	{
+		name:      "high-contrast",
+		base:      tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack),
+		good:      tcell.StyleDefault.Foreground(tcell.ColorAqua).Background(tcell.ColorBlack).Bold(true),
+		bad:       tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorBlack).Bold(true).Underline(true),
+		accent:    tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack).Bold(true),
+		highlight: tcell.StyleDefault.Foreground(tcell.ColorBlack).Background(tcell.ColorYellow),
+		locked:    tcell.StyleDefault.Foreground(tcell.ColorSilver).Background(tcell.ColorBlack),
+	},
+	{
+		name:      "deuteranopia",
+		base:      tcell.StyleDefault,
+		good:      tcell.StyleDefault.Foreground(tcell.ColorDodgerBlue),
+		bad:       tcell.StyleDefault.Foreground(tcell.ColorOrange),
+		accent:    tcell.StyleDefault.Foreground(tcell.ColorSkyblue),
+		highlight: tcell.StyleDefault.Foreground(tcell.ColorYellow),
+		locked:    tcell.StyleDefault.Dim(true),
+	},
+}
+
+var monochrome = palette{
+	name:      "monochrome",
+	base:      tcell.StyleDefault,
+	good:      tcell.StyleDefault.Bold(true),
+	bad:       tcell.StyleDefault.Reverse(true),
+	accent:    tcell.StyleDefault.Underline(true),
+	highlight: tcell.StyleDefault.Bold(true).Underline(true),
+	locked:    tcell.StyleDefault.Dim(true),
+}
+
+func NoColorRequested() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+func (ui *UI) UseMonochrome() {
+	ui.Monochrome = true
+	ui.clearStyle = tcell.StyleDefault
+	if ui.screen != nil {
+		ui.screen.SetStyle(ui.clearStyle)
+	}
+}
+
+func (ui *UI) palette() palette {
+	if ui.Monochrome {
+		return monochrome
+	}
+	for _, candidate := range palettes {
+		if candidate.name == ui.settings.Palette {
+			return candidate
+		}
+	}
+	return palettes[0]
+}
+
+func (ui *UI) cyclePalette() {
+	if ui.Monochrome {
+		ui.setStatus(engine.ErrorStatus(tr("colors are disabled (monochrome mode)")))
+		return
+	}
+	next := palettes[0]
+	for index, candidate := range palettes {
+		if candidate.name == ui.palette().name {
+			next = palettes[(index+1)%len(palettes)]
+		}
+	}
+	ui.settings.Palette = next.name
+	label := tr("palette: %s", next.name)
+	ui.setStatus(ui.savedSetting(label))
+}
+
+func (ui *UI) workerStyle(worker engine.WorkerState) tcell.Style {
+	colors := ui.palette()
+	switch {
+	case worker.Owned == 0:
+		return colors.locked
+	case worker.Running:
+		return colors.good
+	case ui.workerAffordable(worker):
+		return colors.base.Bold(true)
+	}
+	return colors.base
+}
+
+func (ui *UI) workerAffordable(worker engine.WorkerState) bool {
+	return ui.game.DevMode || engine.CanAfford(worker.Definition.Cost, ui.game.Resources)
+}
@@ -0,0 +1,239 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"archuser.org/go-game/engine"
+	"archuser.org/go-game/save"
+)
+
+const plainRefresh = 30 * time.Second
+
+type plainCommand struct {
+	name        string
+	description string
+	run         func(ui *UI, args []string) engine.Status
+}
+
+var plainCommands = []plainCommand{
+	{"status", "print the full game state", nil},
+	{"industry", "select industry by number, or next/prev", (*UI).plainIndustry},
+	{"worker", "select worker by number, or next/prev", (*UI).plainWorker},
+	{"buy", "buy the selected worker, or buy <worker> [count]", (*UI).consoleBuy},
+	{"run", "run the selected worker", plainAction(actionRun)},
+	{"run-lowest", "run the lowest idle manual worker", plainAction(actionRunLowest)},
+	{"upgrade", "upgrade the selected worker", plainAction(actionUpgrade)},
+	{"buy-milestone", "buy up to the next owned milestone", plainAction(actionBuyMilestone)},
+	{"buy-mode", "toggle buy mode between 1x and 100%", plainAction(actionBuyMode)},
+	{"save", "save the game", plainAction(actionSave)},
+	{"load", "load the saved game", plainAction(actionLoad)},
+	{"export", "export statistics", plainAction(actionExport)},
+	{"notation", "toggle scientific notation", plainAction(actionNotation)},
+	{"help", "list commands", nil},
+	{"quit", "leave the game", nil},
+}
+
+func NewPlainUI(game *engine.Engine, profile save.Profile, settings Settings) (*UI, error) {
+	keys := defaultKeymap()
+	if err := keys.apply(settings.Keys); err != nil {
+		return nil, fmt.Errorf("apply key bindings: %w", err)
+	}
+	now := time.Now()
+	ui := &UI{game: game, profile: profile, keys: keys, settings: settings, startedAt: now, lastSavedAt: now, clock: newSimClock(game.Now())}
+	if profile.Hardcore() {
+		game.Hardcore = true
+		ui.AutosaveEvery = hardcoreAutosave
+	}
+	ui.observeGame()
+	return ui, nil
+}
+
+func plainAction(act action) func(ui *UI, args []string) engine.Status {
+	return func(ui *UI, args []string) engine.Status {
+		ui.perform(act)
+		return ui.plainOutcome()
+	}
+}
+
+func (ui *UI) plainOutcome() engine.Status {
+	if ui.mode == modeConfirm {
+		return engine.InfoStatus(tr("%s %s. Type yes to confirm or no to cancel.", ui.dialog.title, strings.Join(ui.dialog.lines, "; ")))
+	}
+	return ui.currentStatus()
+}
+
+func (ui *UI) RunPlain(in io.Reader, out io.Writer) error {
+	tick := time.NewTicker(ui.tickInterval())
+	defer tick.Stop()
+	refresh := time.NewTicker(plainRefresh)
+	defer refresh.Stop()
+
+	lines := readLines(in)
+
+	fmt.Fprintln(out, tr("Go Game - plain mode. Type help for commands."))
+	ui.printState(out)
+	for {
+		select {
+		case <-tick.C:
+			now := time.Now()
+			ui.step(now)
+			ui.game.TakeCompletions()
+			ui.afterTick(now)
+			ui.observeTick(now)
+			ui.printNotices(out)
+			if ui.runEnded {
+				fmt.Fprintln(out, tr("Hardcore run ended: %s.", ui.profile.EndReason))
+				return nil
+			}
+		case <-refresh.C:
+			fmt.Fprintln(out, ui.plainResources())
+		case call := <-ui.apiCalls:
+			ui.serveAPI(call)
+		case message, ok := <-ui.coopIncoming():
+			printed := ui.lastStatusAt
+			ui.handleCoop(message, ok)
+			if ui.lastStatusAt.After(printed) {
+				fmt.Fprintln(out, ui.statusMessage)
+			}
+		case line, ok := <-lines:
+			if !ok && ui.KeepAlive {
+				lines = nil
+				continue
+			}
+			if !ok {
+				return nil
+			}
+			if ui.handlePlainLine(out, line) {
+				return nil
+			}
+		}
+	}
+}
+
+func (ui *UI) handlePlainLine(out io.Writer, line string) bool {
+	fields := strings.Fields(strings.ToLower(line))
+	if len(fields) == 0 {
+		return false
+	}
+	if ui.mode == modeConfirm {
+		ui.answerPlainConfirm(out, fields[0])
+		return false
+	}
+	switch fields[0] {
+	case "quit", "exit":
+		return true
+	case "help":
+		ui.printCommands(out)
+		return false
+	case "status":
+		ui.printState(out)
+		return false
+	}
+	for _, command := range plainCommands {
+		if command.name == fields[0] {
+			if status := command.run(ui, fields[1:]); status.Message != "" {
+				fmt.Fprintln(out, status.Message)
+			}
+			ui.printNotices(out)
+			return false
+		}
+	}
+	fmt.Fprintln(out, tr("unknown command %q, type help for commands", fields[0]))
+	return false
+}
+
+func (ui *UI) answerPlainConfirm(out io.Writer, answer string) {
+	ui.mode = modeMain
+	if answer == "y" || answer == "yes" {
+		ui.dialog.onConfirm()
+	} else {
+		ui.setStatus(engine.InfoStatus(tr("cancelled")))
+	}
+	fmt.Fprintln(out, ui.statusMessage)
+}
+
+func (ui *UI) plainIndustry(args []string) engine.Status {
+	if len(args) == 0 {
+		return engine.ErrorStatus(tr("usage: industry <number|next|prev>"))
+	}
+	switch args[0] {
+	case "next":
+		ui.shiftIndustry(1)
+	case "prev":
+		ui.shiftIndustry(-1)
+	default:
+		number, err := strconv.Atoi(args[0])
+		if err != nil || number < 1 || number > len(ui.game.Industries) {
+			return engine.ErrorStatus(tr("industry must be 1 to %d", len(ui.game.Industries)))
+		}
+		ui.selectIndustry(number - 1)
+	}
+	return engine.InfoStatus(tr("industry %s", ui.game.Industries[ui.activeIndustry].Name))
+}
+
+func (ui *UI) plainWorker(args []string) engine.Status {
+	workers := ui.game.Industries[ui.activeIndustry].Workers
+	if len(args) == 0 {
+		return engine.ErrorStatus(tr("usage: worker <number|next|prev>"))
+	}
+	switch args[0] {
+	case "next":
+		ui.shiftWorker(1)
+	case "prev":
+		ui.shiftWorker(-1)
+	default:
+		number, err := strconv.Atoi(args[0])
+		if err != nil || number < 1 || number > len(workers) {
+			return engine.ErrorStatus(tr("worker must be 1 to %d", len(workers)))
+		}
+		ui.selectedWorker = number - 1
+	}
+	return engine.InfoStatus(ui.plainWorkerLine(workers[ui.selectedWorker]))
+}
+
+func (ui *UI) printCommands(out io.Writer) {
+	fmt.Fprintln(out, tr("Commands:"))
+	for _, command := range plainCommands {
+		fmt.Fprintf(out, "  %s: %s\n", command.name, tr(command.description))
+	}
+}
+
+func (ui *UI) printState(out io.Writer) {
+	industry := ui.game.Industries[ui.activeIndustry]
+	fmt.Fprintln(out, tr("Industry %d of %d: %s. %s.", ui.activeIndustry+1, len(ui.game.Industries), industry.Name, ui.buyModeLabel()))
+	fmt.Fprintln(out, ui.plainResources())
+	for index, worker := range industry.Workers {
+		line := tr("Worker %d: %s", index+1, ui.plainWorkerLine(worker))
+		if index == ui.selectedWorker {
+			line = tr("Worker %d, selected: %s", index+1, ui.plainWorkerLine(worker))
+		}
+		fmt.Fprintln(out, line)
+	}
+}
+
+func (ui *UI) plainWorkerLine(worker engine.WorkerState) string {
+	line := strings.ReplaceAll(strings.TrimSpace(strings.TrimPrefix(ui.workerLine(worker), "*")), " | ", ", ")
+	if ui.workerAffordable(worker) {
+		return line + ", " + tr("affordable")
+	}
+	return line
+}
+
+func (ui *UI) plainResources() string {
+	rates := ui.currentRates()
+	parts := make([]string, 0, len(ui.game.Resources))
+	for _, resource := range engine.SortedKeys(ui.game.Resources) {
+		parts = append(parts, tr("%s %s at %s", resource, ui.formatNumber(ui.game.Resources[resource]), ui.formatRate(rates[resource])))
+	}
+	return tr("Resources: %s.", strings.Join(parts, ", "))
+}
+
+func (ui *UI) printNotices(out io.Writer) {
+	for _, notice := range ui.game.TakeNotices() {
+		fmt.Fprintf(out, "Notice: %s\n", notice.Message)
+	}
+}
@@ -0,0 +1,59 @@
+package tui_test
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"archuser.org/go-game/engine"
+	"archuser.org/go-game/save"
+	"archuser.org/go-game/tui"
+)
+
+func recordRun(t *testing.T) *engine.Replay {
+	t.Helper()
+	game, err := engine.NewFromFile("../config/game.yml", engine.Options{Start: time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC), Seed: 1})
+	if err != nil {
+		t.Fatalf("build game: %v", err)
+	}
+	if err := game.StartRecording(); err != nil {
+		t.Fatalf("start recording: %v", err)
+	}
+	game.StartRun(0, 0, game.Now())
+	game.Step(time.Second)
+	path := filepath.Join(t.TempDir(), "run.replay")
+	if err := game.SaveReplay(path); err != nil {
+		t.Fatal(err)
+	}
+	replay, err := engine.LoadReplay(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return replay
+}
+
+func TestPlainReplay(t *testing.T) {
+	game, err := engine.NewFromFile("../config/game.yml", engine.Options{Start: time.Now(), Seed: 1})
+	if err != nil {
+		t.Fatalf("build game: %v", err)
+	}
+	if err := game.StartPlayback(recordRun(t), time.Now()); err != nil {
+		t.Fatalf("start playback: %v", err)
+	}
+	ui, err := tui.NewPlainUI(game, save.Profile{Mode: save.ProfileNormal}, tui.Settings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	in, input := io.Pipe()
+	go func() {
+		time.Sleep(1500 * time.Millisecond)
+		io.WriteString(input, "quit\n")
+	}()
+	if err := ui.RunPlain(in, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if coal := game.Resources["coal"]; coal != 25 {
+		t.Fatalf("coal after replay is %d, want 25", coal)
+	}
+}
@@ -0,0 +1,30 @@
+package tui
+
+func (ui *UI) openPlugins() {
+	ui.statsScroll = 0
+	ui.mode = modePlugins
+}
+
+func (ui *UI) drawPlugins(width, height int) {
+	ui.drawScrollLines(width, height, tr("Plugins"), tr("↑/↓ PgUp/PgDn scroll | any other key returns"), ui.pluginLines())
+}
+
+func (ui *UI) pluginLines() []detailLine {
+	plain := ui.palette().base
+	heading := plain.Bold(true)
+	panels := ui.game.PluginPanels()
+	if len(panels) == 0 {
+		return []detailLine{{text: tr("no plugin panels"), style: plain}}
+	}
+	var lines []detailLine
+	for _, current := range panels {
+		lines = append(lines, detailLine{text: current.Name + ":", style: heading})
+		if current.Failed {
+			lines = append(lines, detailLine{text: "  " + tr("disabled"), style: ui.palette().bad})
+		}
+		for _, line := range current.Panel {
+			lines = append(lines, detailLine{text: "  " + line, style: plain})
+		}
+	}
+	return lines
+}
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+type quantityPrompt struct {
+	industry int
+	worker   int
+	count    int
+	typed    bool
+}
+
+func (ui *UI) openQuantity() {
+	ui.quantity = quantityPrompt{industry: ui.activeIndustry, worker: ui.selectedWorker, count: 1}
+	ui.mode = modeQuantity
+}
+
+func (ui *UI) handleQuantityKey(event *tcell.EventKey) {
+	prompt := &ui.quantity
+	switch event.Key() {
+	case tcell.KeyEnter:
+		ui.mode = modeMain
+		ui.setStatus(ui.game.BuyCount(prompt.industry, prompt.worker, prompt.count))
+	case tcell.KeyEscape:
+		ui.mode = modeMain
+		ui.setStatus(engine.InfoStatus(tr("cancelled")))
+	case tcell.KeyUp:
+		prompt.adjust(1)
+	case tcell.KeyDown:
+		prompt.adjust(-1)
+	case tcell.KeyPgUp:
+		prompt.adjust(10)
+	case tcell.KeyPgDn:
+		prompt.adjust(-10)
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		prompt.count /= 10
+		prompt.typed = true
+	case tcell.KeyRune:
+		ui.quantityRune(event.Rune())
+	}
+}
+
+func (ui *UI) quantityRune(r rune) {
+	prompt := &ui.quantity
+	switch {
+	case r >= '0' && r <= '9':
+		if !prompt.typed {
+			prompt.count = 0
+			prompt.typed = true
+		}
+		prompt.count = engine.MinInt(prompt.count*10+int(r-'0'), engine.MaxQuantity)
+	case r == '+' || r == '=':
+		prompt.adjust(1)
+	case r == '-':
+		prompt.adjust(-1)
+	case r == 'm':
+		cost := ui.game.Industries[prompt.industry].Workers[prompt.worker].Definition.Cost
+		prompt.count = engine.MaxInt(engine.MaxAffordable(cost, ui.game.Resources), 1)
+		prompt.typed = false
+	}
+}
+
+func (p *quantityPrompt) adjust(delta int) {
+	p.count = clamp(p.count+delta, 1, engine.MaxQuantity)
+	p.typed = false
+}
+
+func (ui *UI) quantityLines() []string {
+	prompt := ui.quantity
+	worker := ui.game.Industries[prompt.industry].Workers[prompt.worker]
+	cost := engine.MultiplyCost(worker.Definition.Cost, prompt.count)
+	lines := []string{tr("owned %s -> %s", ui.formatNumber(worker.Owned), ui.formatNumber(worker.Owned+prompt.count))}
+	for _, resource := range engine.SortedKeys(cost) {
+		have := ui.game.Resources[resource]
+		marker := ""
+		if have < cost[resource] && !ui.game.DevMode {
+			marker = " (" + tr("short") + ")"
+		}
+		lines = append(lines, fmt.Sprintf("%s %s: %s -> %s%s", resource, ui.formatNumber(cost[resource]), ui.formatNumber(have), ui.formatNumber(have-cost[resource]), marker))
+	}
+	lines = append(lines, tr("max affordable %s", ui.formatNumber(engine.MaxAffordable(worker.Definition.Cost, ui.game.Resources))))
+	return lines
+}
+
+func (ui *UI) drawQuantity(width, height int) {
+	worker := ui.game.Industries[ui.quantity.industry].Workers[ui.quantity.worker]
+	title := tr("Buy %s: %d", worker.Definition.WorkerName, ui.quantity.count)
+	ui.drawDialog(width, height, title, ui.quantityLines(), tr("0-9 type | +/- adjust | m max | enter buy | esc cancel"))
+}
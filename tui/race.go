@@ -0,0 +1,268 @@
+package tui
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+const (
+	noticeRace      = "race"
+	raceNetWorth    = "networth"
+	raceInterval    = 500 * time.Millisecond
+	raceTimeout     = 2 * time.Second
+	DefaultRaceGoal = "networth:1000000"
+)
+
+type RaceTarget struct {
+	Resource string `json:"resource"`
+	Amount   int    `json:"amount"`
+}
+
+type raceStatus struct {
+	Name     string        `json:"name"`
+	Board    string        `json:"board"`
+	Target   RaceTarget    `json:"target"`
+	Progress int           `json:"progress"`
+	NetWorth int           `json:"netWorth"`
+	Elapsed  time.Duration `json:"elapsedNs"`
+	Finished time.Duration `json:"finishedNs,omitempty"`
+}
+
+type raceLink struct {
+	conn     net.Conn
+	name     string
+	target   RaceTarget
+	started  time.Time
+	finished time.Duration
+	opponent raceStatus
+	joined   bool
+	decided  bool
+	left     bool
+}
+
+func ParseRaceTarget(spec string) (RaceTarget, error) {
+	resource, amount, ok := strings.Cut(spec, ":")
+	if !ok || resource == "" {
+		return RaceTarget{}, fmt.Errorf("race target %q must look like resource:amount", spec)
+	}
+	value, err := strconv.Atoi(amount)
+	if err != nil || value <= 0 {
+		return RaceTarget{}, fmt.Errorf("race target %q needs a positive amount", spec)
+	}
+	return RaceTarget{Resource: resource, Amount: value}, nil
+}
+
+func (t RaceTarget) String() string {
+	return fmt.Sprintf("%s %s", t.Resource, engine.FormatNumber(t.Amount, false))
+}
+
+func (ui *UI) HostRace(addr, name string, target RaceTarget) (io.Closer, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listen race: %w", err)
+	}
+	defer listener.Close()
+	fmt.Fprintf(os.Stderr, "waiting for an opponent on %s\n", listener.Addr())
+	conn, err := listener.Accept()
+	if err != nil {
+		return nil, fmt.Errorf("accept race: %w", err)
+	}
+	ui.startRace(conn, name, target)
+	return conn, nil
+}
+
+func (ui *UI) JoinRace(addr, name string, target RaceTarget) (io.Closer, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("connect race: %w", err)
+	}
+	ui.startRace(conn, name, target)
+	return conn, nil
+}
+
+func (ui *UI) startRace(conn net.Conn, name string, target RaceTarget) {
+	if ui.apiCalls == nil {
+		ui.apiCalls = make(chan apiCall)
+	}
+	link := &raceLink{conn: conn, name: name, target: target, started: ui.game.Now()}
+	ui.race = link
+	go ui.sendRace(link)
+	go ui.receiveRace(link)
+}
+
+func (ui *UI) sendRace(link *raceLink) {
+	encoder := json.NewEncoder(link.conn)
+	ticker := time.NewTicker(raceInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), raceTimeout)
+		reply, ok := ui.callAPI(ctx, func(ui *UI) (int, any) { return 0, ui.raceStatus() })
+		cancel()
+		if !ok {
+			continue
+		}
+		if err := encoder.Encode(reply.body); err != nil {
+			return
+		}
+	}
+}
+
+func (ui *UI) receiveRace(link *raceLink) {
+	scanner := bufio.NewScanner(link.conn)
+	for scanner.Scan() {
+		var status raceStatus
+		if err := json.Unmarshal(scanner.Bytes(), &status); err != nil {
+			continue
+		}
+		ui.applyRace(func(ui *UI) { ui.updateOpponent(status) })
+	}
+	ui.applyRace(func(ui *UI) {
+		link.left = true
+		ui.game.Notify(noticeRace, tr("%s left the race", ui.opponentName()))
+		ui.decideRace()
+	})
+}
+
+func (ui *UI) applyRace(apply func(ui *UI)) {
+	ctx, cancel := context.WithTimeout(context.Background(), raceTimeout)
+	defer cancel()
+	ui.callAPI(ctx, func(ui *UI) (int, any) {
+		apply(ui)
+		return 0, nil
+	})
+}
+
+func (ui *UI) raceStatus() raceStatus {
+	return raceStatus{
+		Name:     ui.race.name,
+		Board:    ui.leaderboardBoard(),
+		Target:   ui.race.target,
+		Progress: ui.raceProgress(),
+		NetWorth: ui.game.NetWorth(),
+		Elapsed:  ui.raceElapsed(),
+		Finished: ui.race.finished,
+	}
+}
+
+func (ui *UI) raceProgress() int {
+	if ui.race.target.Resource == raceNetWorth {
+		return ui.game.NetWorth()
+	}
+	return ui.game.Stats.Earned[ui.race.target.Resource]
+}
+
+func (ui *UI) raceElapsed() time.Duration {
+	return max(ui.game.Now().Sub(ui.race.started), time.Millisecond)
+}
+
+func (ui *UI) checkRaceFinish() {
+	link := ui.race
+	if link == nil {
+		return
+	}
+	if link.finished == 0 && ui.raceProgress() >= link.target.Amount {
+		link.finished = ui.raceElapsed()
+		if !link.decided {
+			ui.game.Notify(noticeRace, tr("you reached %s in %s", link.target, link.finished.Truncate(time.Second)))
+		}
+	}
+	ui.decideRace()
+}
+
+func (ui *UI) decideRace() {
+	link := ui.race
+	if link.decided {
+		return
+	}
+	mine, theirs := link.finished, link.opponent.Finished
+	switch {
+	case mine > 0 && theirs > 0:
+		link.decided = true
+		switch {
+		case mine < theirs:
+			ui.game.Notify(noticeRace, tr("you won the race to %s in %s", link.target, mine.Truncate(time.Second)))
+		case theirs < mine:
+			ui.game.Notify(noticeRace, tr("%s won the race to %s in %s", ui.opponentName(), link.target, theirs.Truncate(time.Second)))
+		default:
+			ui.game.Notify(noticeRace, tr("the race to %s ended in a tie", link.target))
+		}
+	case mine > 0 && (link.left || link.opponent.Elapsed > mine):
+		link.decided = true
+		ui.game.Notify(noticeRace, tr("you won the race to %s in %s", link.target, mine.Truncate(time.Second)))
+	case theirs > 0 && ui.raceElapsed() > theirs:
+		link.decided = true
+		ui.game.Notify(noticeRace, tr("%s won the race to %s in %s", ui.opponentName(), link.target, theirs.Truncate(time.Second)))
+	}
+}
+
+func (ui *UI) raceLocked(action string) (engine.Status, bool) {
+	if ui.race == nil {
+		return engine.Status{}, false
+	}
+	return engine.ErrorStatus(tr("%s disabled during a race", action)), true
+}
+
+func (ui *UI) updateOpponent(status raceStatus) {
+	link := ui.race
+	if !link.joined {
+		link.joined = true
+		ui.game.Notify(noticeRace, tr("%s joined the race to %s", status.Name, link.target))
+		if status.Board != ui.leaderboardBoard() || status.Target != link.target {
+			ui.game.Notify(noticeRace, tr("%s is racing on a different config or target", status.Name))
+		}
+	}
+	link.opponent = status
+	ui.decideRace()
+}
+
+func (ui *UI) opponentName() string {
+	if ui.race.opponent.Name == "" {
+		return tr("opponent")
+	}
+	return ui.race.opponent.Name
+}
+
+func (ui *UI) RaceStanding() string {
+	link := ui.race
+	if link == nil {
+		return ""
+	}
+	mine := racePercent(ui.raceProgress(), link.target.Amount)
+	if !link.joined {
+		return tr("race %d%%", mine)
+	}
+	theirs := racePercent(link.opponent.Progress, link.target.Amount)
+	standing := tr("race %d%% vs %s %d%% (%s)", mine, ui.opponentName(), theirs, ui.formatNumber(link.opponent.NetWorth))
+	if link.left {
+		standing += " " + tr("(left)")
+	}
+	return standing
+}
+
+func racePercent(progress, target int) int {
+	return min(progress*100/target, 100)
+}
+
+func StartRace(ui *UI, host, join, name, goal string) (io.Closer, error) {
+	if ui.game.DevMode {
+		return nil, fmt.Errorf("races are not available in developer mode")
+	}
+	target, err := ParseRaceTarget(goal)
+	if err != nil {
+		return nil, err
+	}
+	if host != "" {
+		return ui.HostRace(host, name, target)
+	}
+	return ui.JoinRace(join, name, target)
+}
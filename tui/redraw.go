@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+func (ui *UI) markDirty(event any) {
+	if _, ok := event.(engine.Ticked); !ok {
+		ui.dirty = true
+	}
+}
+
+func (ui *UI) needsFrame(now time.Time) bool {
+	return ui.dirty || len(ui.game.Notices) > 0 || ui.animating() ||
+		!now.Truncate(time.Second).Equal(ui.drawnAt.Truncate(time.Second))
+}
+
+func (ui *UI) animating() bool {
+	if len(ui.flashes) > 0 {
+		return true
+	}
+	if ui.settings.ReducedMotion || ui.activeIndustry >= len(ui.game.Industries) {
+		return false
+	}
+	for _, worker := range ui.game.Industries[ui.activeIndustry].Workers {
+		if worker.Running {
+			return true
+		}
+	}
+	return false
+}
+
+func (ui *UI) drawn(now time.Time) {
+	ui.dirty = false
+	ui.drawnAt = now
+}
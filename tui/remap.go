@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+func (ui *UI) openKeymap() {
+	ui.mode = modeKeymap
+	ui.remapIndex = 0
+	ui.remapWaiting = false
+}
+
+func (ui *UI) closeKeymap() {
+	ui.mode = modeMain
+	ui.remapWaiting = false
+	ui.footer = [2][]footerItem{}
+	ui.settings.Keys = ui.keys.export()
+	if err := ui.settings.Save(); err != nil {
+		ui.setStatus(engine.ErrorStatus(tr("save settings failed: %v", err)))
+		return
+	}
+	ui.setStatus(engine.SuccessStatus(tr("key bindings saved")))
+}
+
+func (ui *UI) handleKeymapKey(event *tcell.EventKey) {
+	act := actionOrder[ui.remapIndex]
+	if ui.remapWaiting {
+		ui.remapWaiting = false
+		key := keyRune(event)
+		if event.Key() != tcell.KeyRune && key == event.Rune() {
+			ui.setStatus(engine.InfoStatus(tr("rebind cancelled")))
+			return
+		}
+		if owner, ok := ui.keys.bind(act, key); !ok {
+			ui.setStatus(engine.ErrorStatus(tr("%s is already bound to %s", keyLabel(key), owner)))
+			return
+		}
+		ui.setStatus(engine.SuccessStatus(tr("bound %s to %s", act, keyLabel(key))))
+		return
+	}
+	switch event.Key() {
+	case tcell.KeyEscape:
+		ui.closeKeymap()
+	case tcell.KeyUp:
+		ui.remapIndex = clamp(ui.remapIndex-1, 0, len(actionOrder)-1)
+	case tcell.KeyDown:
+		ui.remapIndex = clamp(ui.remapIndex+1, 0, len(actionOrder)-1)
+	case tcell.KeyEnter:
+		ui.remapWaiting = true
+		ui.setStatus(engine.InfoStatus(tr("press a key for %s (non-character key cancels)", act)))
+	case tcell.KeyBackspace, tcell.KeyBackspace2, tcell.KeyDelete:
+		if owner, ok := ui.keys.reset(act); !ok {
+			ui.setStatus(engine.ErrorStatus(tr("default key for %s is taken by %s", act, owner)))
+			return
+		}
+		ui.setStatus(engine.InfoStatus(tr("reset %s to %s", act, ui.keys.labels(act))))
+	}
+}
+
+func (ui *UI) drawKeymap(width, height int) {
+	ui.drawText(2, 1, "Key Bindings", tcell.StyleDefault.Bold(true))
+	ui.drawText(2, 2, truncate("↑/↓ select | enter rebind | backspace reset | esc save and return", width-4), tcell.StyleDefault)
+	rows := height - 7
+	start := 0
+	if ui.remapIndex >= rows {
+		start = ui.remapIndex - rows + 1
+	}
+	for index := start; index < len(actionOrder) && index-start < rows; index++ {
+		act := actionOrder[index]
+		style := tcell.StyleDefault
+		if index == ui.remapIndex {
+			style = style.Reverse(true)
+		}
+		keys := ui.keys.labels(act)
+		if index == ui.remapIndex && ui.remapWaiting {
+			keys = "..."
+		}
+		line := fmt.Sprintf("%-12s %-30s %s", keys, tr(actionDescriptions[act]), act)
+		ui.drawText(4, 4+index-start, truncate(line, width-6), style)
+	}
+	ui.drawText(2, height-2, truncate(ui.statusMessage, width-4), ui.palette().good)
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+type cell struct {
+	char  rune
+	marks string
+	style tcell.Style
+}
+
+type frameBuffer struct {
+	width    int
+	height   int
+	current  []cell
+	previous []cell
+}
+
+func (ui *UI) beginFrame(width, height int) {
+	frame := &ui.frame
+	if frame.width != width || frame.height != height || frame.previous == nil {
+		frame.width, frame.height = width, height
+		frame.current = make([]cell, width*height)
+		frame.previous = make([]cell, width*height)
+		ui.screen.Clear()
+		blankCells(frame.previous, ui.clearStyle)
+	}
+	blankCells(frame.current, ui.clearStyle)
+}
+
+func (ui *UI) setCell(x, y int, char rune, style tcell.Style) {
+	frame := &ui.frame
+	if x < 0 || y < 0 || x >= frame.width || y >= frame.height {
+		return
+	}
+	frame.current[y*frame.width+x] = cell{char: char, style: style}
+}
+
+func (ui *UI) setGlyph(x, y int, char glyph, style tcell.Style) {
+	frame := &ui.frame
+	if x < 0 || y < 0 || x+char.width > frame.width || y >= frame.height {
+		return
+	}
+	frame.current[y*frame.width+x] = cell{char: char.char, marks: char.marks, style: style}
+	for offset := 1; offset < char.width; offset++ {
+		frame.current[y*frame.width+x+offset] = cell{style: style}
+	}
+}
+
+func (ui *UI) flushFrame() {
+	frame := &ui.frame
+	for index, next := range frame.current {
+		if next == frame.previous[index] || next.char == 0 {
+			continue
+		}
+		ui.screen.SetContent(index%frame.width, index/frame.width, next.char, combining(next.marks), next.style)
+	}
+	frame.current, frame.previous = frame.previous, frame.current
+}
+
+func combining(marks string) []rune {
+	if marks == "" {
+		return nil
+	}
+	return []rune(marks)
+}
+
+func (ui *UI) invalidateFrame() {
+	ui.frame.previous = nil
+}
+
+func blankCells(cells []cell, style tcell.Style) {
+	for index := range cells {
+		cells[index] = cell{char: ' ', style: style}
+	}
+}
@@ -0,0 +1,36 @@
+package tui_test
+
+import (
+	"testing"
+	"time"
+
+	"archuser.org/go-game/engine"
+	"archuser.org/go-game/tui"
+)
+
+func busyHarness(t testing.TB) *tui.Harness {
+	t.Helper()
+	game := newGame(t, nil)
+	bot, err := engine.LookupStrategy("greedy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	game.Autoplay(bot, time.Hour)
+	return startHarness(t, game)
+}
+
+func TestDrawAllocs(t *testing.T) {
+	h := busyHarness(t)
+	h.Render()
+	if allocs := testing.AllocsPerRun(100, h.Render); allocs != 0 {
+		t.Fatalf("drawing a frame allocates %.1f times, want 0", allocs)
+	}
+}
+
+func BenchmarkDraw(b *testing.B) {
+	h := busyHarness(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		h.Render()
+	}
+}
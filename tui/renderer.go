@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+type Renderer interface {
+	Size() (width, height int)
+	SetContent(x, y int, primary rune, combining []rune, style tcell.Style)
+	SetStyle(style tcell.Style)
+	Clear()
+	Show()
+	Sync()
+	PollEvent() tcell.Event
+	CanDisplay(char rune, checkFallbacks bool) bool
+	Beep() error
+	Tty() (tcell.Tty, bool)
+	EnableMouse(flags ...tcell.MouseFlags)
+	Suspend() error
+	Resume() error
+	Fini()
+}
+
+func newTerminalRenderer() (Renderer, error) {
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		return nil, fmt.Errorf("create screen: %w", err)
+	}
+	if err := screen.Init(); err != nil {
+		return nil, fmt.Errorf("init screen: %w", err)
+	}
+	return screen, nil
+}
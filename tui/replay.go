@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+var playbackActions = map[action]bool{
+	actionIndustryPrev: true,
+	actionIndustryNext: true,
+	actionWorkerPrev:   true,
+	actionWorkerNext:   true,
+	actionFirstWorker:  true,
+	actionLastWorker:   true,
+	actionSearch:       true,
+	actionConsole:      true,
+	actionFilter:       true,
+	actionSort:         true,
+	actionPause:        true,
+	actionSlower:       true,
+	actionFaster:       true,
+	actionExport:       true,
+	actionHelp:         true,
+	actionKeymap:       true,
+	actionNotation:     true,
+	actionPalette:      true,
+	actionLayout:       true,
+	actionLog:          true,
+	actionHistory:      true,
+	actionChart:        true,
+	actionAchievements: true,
+	actionStats:        true,
+	actionIndustry:     true,
+	actionPlugins:      true,
+	actionLeaderboard:  true,
+	actionDetails:      true,
+	actionSuspend:      true,
+}
+
+func (ui *UI) step(now time.Time) {
+	simNow := ui.clock.advance(now)
+	if ui.coop != nil {
+		return
+	}
+	if !ui.game.Replaying() {
+		ui.game.Update(simNow)
+		return
+	}
+	if ui.game.PlaybackDone() {
+		return
+	}
+	for _, status := range ui.game.PlaybackUntil(simNow) {
+		ui.setStatus(engine.Status{Message: tr("replay: %s", status.Message), Class: status.Class})
+	}
+	if ui.game.PlaybackDone() {
+		ui.setStatus(engine.InfoStatus(tr("replay finished")))
+	}
+}
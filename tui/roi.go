@@ -0,0 +1,33 @@
+package tui
+
+import (
+	"archuser.org/go-game/engine"
+)
+
+func (ui *UI) bestInvestment() (engine.Investment, bool) {
+	var best engine.Investment
+	found := false
+	consider := func(candidate engine.Investment, ok bool) {
+		if ok && (!found || candidate.Payback < best.Payback) {
+			best, found = candidate, true
+		}
+	}
+	for index := range ui.game.Industries[ui.activeIndustry].Workers {
+		buy, ok := ui.game.BuyPayback(ui.activeIndustry, index)
+		consider(engine.Investment{Worker: index, Payback: buy}, ok)
+		upgrade, ok := ui.game.UpgradePayback(ui.activeIndustry, index)
+		consider(engine.Investment{Worker: index, Upgrade: true, Payback: upgrade}, ok)
+	}
+	return best, found
+}
+
+func (ui *UI) investmentLabel(best engine.Investment) string {
+	return ui.texts.investments.get(best, func() string { return investmentText(best) })
+}
+
+func investmentText(best engine.Investment) string {
+	if best.Upgrade {
+		return tr("best upgrade, pays back in %s", best.Payback)
+	}
+	return tr("best buy, pays back in %s", best.Payback)
+}
@@ -0,0 +1,45 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+)
+
+func (ui *UI) markSynced(now time.Time) {
+	ui.syncedAt = now
+	ui.syncedRevision = ui.game.Revision()
+}
+
+func (ui *UI) saveIndicator(now time.Time) string {
+	switch {
+	case ui.game.Revision() != ui.syncedRevision:
+		return tr("unsaved changes")
+	case ui.syncedAt.IsZero():
+		return tr("not saved")
+	}
+	return tr("saved %s ago", sinceLabel(now.Sub(ui.syncedAt)))
+}
+
+func sinceLabel(elapsed time.Duration) string {
+	switch {
+	case elapsed < time.Minute:
+		return fmt.Sprintf("%ds", int(elapsed.Seconds()))
+	case elapsed < time.Hour:
+		return fmt.Sprintf("%dm", int(elapsed.Minutes()))
+	}
+	return fmt.Sprintf("%dh", int(elapsed.Hours()))
+}
+
+func (ui *UI) drawSaveIndicator(right, y, limit int) int {
+	label := ui.saveIndicator(time.Now())
+	style := ui.palette().locked
+	if ui.game.Revision() != ui.syncedRevision {
+		style = ui.palette().bad
+	}
+	left := right - textWidth(label)
+	if left < limit {
+		return right
+	}
+	ui.drawText(left, y, label, style)
+	return left - 1
+}
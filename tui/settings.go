@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"archuser.org/go-game/config"
+)
+
+type Settings struct {
+	Keys               map[string][]string `yaml:"keys,omitempty"`
+	Scientific         bool                `yaml:"scientific"`
+	ConfirmFraction    float64             `yaml:"confirmFraction"`
+	ReducedMotion      bool                `yaml:"reducedMotion"`
+	SkipUpgradePreview bool                `yaml:"skipUpgradePreview"`
+	Palette            string              `yaml:"palette,omitempty"`
+	FPS                int                 `yaml:"fps,omitempty"`
+	Layout             string              `yaml:"layout,omitempty"`
+	StatusTimeout      time.Duration       `yaml:"statusTimeout,omitempty"`
+	StickyErrors       bool                `yaml:"stickyErrors"`
+	Cues               map[string]string   `yaml:"cues,omitempty"`
+	Leaderboard        LeaderboardSettings `yaml:"leaderboard,omitempty"`
+	path               string
+}
+
+func LoadSettings(path string) (Settings, error) {
+	settings := Settings{ConfirmFraction: defaultConfirmFraction, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return Settings{}, fmt.Errorf("read settings: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return Settings{}, fmt.Errorf("parse settings: %w", err)
+	}
+	settings.path = path
+	return settings, nil
+}
+
+func (s Settings) Save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := config.MarshalYAML(s)
+	if err != nil {
+		return fmt.Errorf("serialize settings: %w", err)
+	}
+	if dir := filepath.Dir(s.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create settings dir: %w", err)
+		}
+	}
+	if err := os.WriteFile(s.path, data, 0o644); err != nil {
+		return fmt.Errorf("write settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	defaultTick = 50 * time.Millisecond
+	minTick     = 10 * time.Millisecond
+	maxTick     = time.Second
+)
+
+func (ui *UI) tickInterval() time.Duration {
+	if ui.TickRate <= 0 {
+		return defaultTick
+	}
+	return min(max(ui.TickRate, minTick), maxTick)
+}
+
+func (ui *UI) simulate(done <-chan struct{}, panics chan<- any) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			panics <- recovered
+		}
+	}()
+	tick := time.NewTicker(ui.tickInterval())
+	defer tick.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-tick.C:
+			ui.withSim(func() { ui.simulateTick(time.Now()) })
+		}
+	}
+}
+
+func (ui *UI) simulateTick(now time.Time) {
+	ui.step(now)
+	ui.collectCompletions(now)
+	ui.afterTick(now)
+	ui.observeTick(now)
+}
+
+func (ui *UI) withSim(run func()) {
+	ui.simMu.Lock()
+	defer ui.simMu.Unlock()
+	run()
+}
+
+func (ui *UI) render() {
+	started := time.Now()
+	var cues []pendingCue
+	ui.withSim(func() {
+		ui.collectNotices()
+		ui.syncCoopCursor()
+		ui.draw()
+		ui.drawn(started)
+		cues = ui.takeCues()
+	})
+	ui.screen.Show()
+	ui.playCues(cues)
+	ui.metrics.frame(time.Since(started))
+}
+
+func (ui *UI) handleEvent(ev tcell.Event) bool {
+	switch event := ev.(type) {
+	case *tcell.EventResize:
+		ui.invalidateFrame()
+		ui.screen.Sync()
+	case *tcell.EventKey:
+		return ui.handleKey(event)
+	case *tcell.EventMouse:
+		ui.handleMouse(event)
+	case *tcell.EventError:
+		return true
+	}
+	return false
+}
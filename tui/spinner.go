@@ -0,0 +1,17 @@
+package tui
+
+import (
+	"time"
+)
+
+const spinnerInterval = 100 * time.Millisecond
+
+var spinnerFrames = []string{"⠋ ", "⠙ ", "⠹ ", "⠸ ", "⠼ ", "⠴ ", "⠦ ", "⠧ ", "⠇ ", "⠏ "}
+
+func (ui *UI) spinner(now time.Time) string {
+	if ui.settings.ReducedMotion || ui.screen == nil {
+		return ""
+	}
+	frame := now.UnixNano() / int64(spinnerInterval) % int64(len(spinnerFrames))
+	return spinnerFrames[frame]
+}
@@ -0,0 +1,345 @@
+package tui
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/gliderlabs/ssh"
+	gossh "golang.org/x/crypto/ssh"
+
+	"archuser.org/go-game/engine"
+	"archuser.org/go-game/save"
+)
+
+const (
+	DefaultSSHHostKey = "ssh_host_ed25519_key"
+	defaultSSHTerm    = "xterm-256color"
+)
+
+var sshProfileName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+type SSHOptions struct {
+	HostKey        string
+	AuthorizedKeys string
+	ConfigPath     string
+	AutosaveEvery  time.Duration
+	SaveFormat     string
+}
+
+type sshHost struct {
+	options SSHOptions
+	mu      sync.Mutex
+	active  map[string]bool
+}
+
+func ServeSSH(addr string, options SSHOptions) error {
+	if err := save.UseFormat(&engine.Engine{}, options.SaveFormat); err != nil {
+		return err
+	}
+	signer, err := loadHostKey(options.HostKey)
+	if err != nil {
+		return err
+	}
+	authorized, err := loadAuthorizedKeys(options.AuthorizedKeys)
+	if err != nil {
+		return err
+	}
+	host := &sshHost{options: options, active: make(map[string]bool)}
+	server := &ssh.Server{
+		Addr:    addr,
+		Handler: host.serve,
+		PublicKeyHandler: func(ctx ssh.Context, key ssh.PublicKey) bool {
+			return authorizedKey(authorized, key)
+		},
+	}
+	server.AddHostKey(signer)
+	if err := server.ListenAndServe(); err != nil {
+		return fmt.Errorf("serve ssh: %w", err)
+	}
+	return nil
+}
+
+func (h *sshHost) serve(session ssh.Session) {
+	pty, windows, ok := session.Pty()
+	if !ok {
+		fmt.Fprintln(session, "go-game needs a terminal: connect with ssh -t")
+		session.Exit(1)
+		return
+	}
+	name := session.User()
+	if !sshProfileName.MatchString(name) {
+		fmt.Fprintf(session, "%q is not a valid profile name\n", name)
+		session.Exit(1)
+		return
+	}
+	if !h.claim(name) {
+		fmt.Fprintf(session, "profile %s is already being played\n", name)
+		session.Exit(1)
+		return
+	}
+	defer h.release(name)
+	if err := h.play(session, name, pty, windows); err != nil {
+		fmt.Fprintf(session.Stderr(), "%v\n", err)
+		session.Exit(1)
+		return
+	}
+	session.Exit(0)
+}
+
+func (h *sshHost) claim(name string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.active[name] {
+		return false
+	}
+	h.active[name] = true
+	return true
+}
+
+func (h *sshHost) release(name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.active, name)
+}
+
+func (h *sshHost) play(session ssh.Session, name string, pty ssh.Pty, windows <-chan ssh.Window) error {
+	profile, err := save.OpenProfile(name, save.ProfileNormal)
+	if err != nil {
+		return fmt.Errorf("open profile: %w", err)
+	}
+	if profile.Ended {
+		return fmt.Errorf("profile %s has ended (%s)", profile.Name, profile.EndReason)
+	}
+	configPath := h.options.ConfigPath
+	if _, err := os.Stat(profile.ConfigPath()); err == nil {
+		configPath = profile.ConfigPath()
+	}
+	game, err := engine.NewFromFile(configPath, engine.RealTime())
+	if err != nil {
+		return fmt.Errorf("build game: %w", err)
+	}
+	if err := save.UseFormat(game, h.options.SaveFormat); err != nil {
+		return err
+	}
+	if profile.Hardcore() {
+		if err := save.Resume(game, profile); err != nil {
+			return fmt.Errorf("resume save: %w", err)
+		}
+	}
+	settings, err := LoadSettings(profile.SettingsPath())
+	if err != nil {
+		return fmt.Errorf("load settings: %w", err)
+	}
+	screen, err := newSSHRenderer(session, pty, windows)
+	if err != nil {
+		return err
+	}
+	ui, err := NewUIWith(screen, game, profile, settings)
+	if err != nil {
+		screen.Fini()
+		return err
+	}
+	ui.ConfigPath = configPath
+	if every := h.options.AutosaveEvery; every > 0 && (ui.AutosaveEvery == 0 || every < ui.AutosaveEvery) {
+		ui.AutosaveEvery = every
+	}
+	ui.OpenMenu()
+	return ui.Guard(session.Stderr(), ui.Run)
+}
+
+func newSSHRenderer(session ssh.Session, pty ssh.Pty, windows <-chan ssh.Window) (Renderer, error) {
+	info, err := tcell.LookupTerminfo(pty.Term)
+	if err != nil {
+		info, err = tcell.LookupTerminfo(defaultSSHTerm)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("look up terminal: %w", err)
+	}
+	screen, err := tcell.NewTerminfoScreenFromTtyTerminfo(newSSHTty(session, pty.Window, windows), info)
+	if err != nil {
+		return nil, fmt.Errorf("create screen: %w", err)
+	}
+	if err := screen.Init(); err != nil {
+		return nil, fmt.Errorf("init screen: %w", err)
+	}
+	return screen, nil
+}
+
+type sshTty struct {
+	session  ssh.Session
+	input    chan []byte
+	pending  []byte
+	mu       sync.Mutex
+	drained  chan struct{}
+	window   ssh.Window
+	onResize func()
+}
+
+func newSSHTty(session ssh.Session, window ssh.Window, windows <-chan ssh.Window) *sshTty {
+	tty := &sshTty{session: session, input: make(chan []byte), drained: make(chan struct{}), window: window}
+	go tty.pump()
+	go tty.watch(windows)
+	return tty
+}
+
+func (t *sshTty) pump() {
+	defer close(t.input)
+	for {
+		chunk := make([]byte, 256)
+		n, err := t.session.Read(chunk)
+		if n > 0 {
+			t.input <- chunk[:n]
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (t *sshTty) watch(windows <-chan ssh.Window) {
+	for window := range windows {
+		t.mu.Lock()
+		t.window = window
+		notify := t.onResize
+		t.mu.Unlock()
+		if notify != nil {
+			notify()
+		}
+	}
+}
+
+func (t *sshTty) Start() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.drained = make(chan struct{})
+	return nil
+}
+
+func (t *sshTty) Drain() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	close(t.drained)
+	return nil
+}
+
+func (t *sshTty) Stop() error {
+	return nil
+}
+
+func (t *sshTty) NotifyResize(notify func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onResize = notify
+}
+
+func (t *sshTty) WindowSize() (tcell.WindowSize, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return tcell.WindowSize{Width: t.window.Width, Height: t.window.Height}, nil
+}
+
+func (t *sshTty) Read(p []byte) (int, error) {
+	if len(t.pending) == 0 {
+		t.mu.Lock()
+		drained := t.drained
+		t.mu.Unlock()
+		select {
+		case chunk, ok := <-t.input:
+			if !ok {
+				return 0, io.EOF
+			}
+			t.pending = chunk
+		case <-drained:
+			return 0, nil
+		}
+	}
+	n := copy(p, t.pending)
+	t.pending = t.pending[n:]
+	return n, nil
+}
+
+func (t *sshTty) Write(p []byte) (int, error) {
+	return t.session.Write(p)
+}
+
+func (t *sshTty) Close() error {
+	return nil
+}
+
+func loadHostKey(path string) (gossh.Signer, error) {
+	payload, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		payload, err = generateHostKey(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read host key: %w", err)
+	}
+	signer, err := gossh.ParsePrivateKey(payload)
+	if err != nil {
+		return nil, fmt.Errorf("parse host key: %w", err)
+	}
+	return signer, nil
+}
+
+func generateHostKey(path string) ([]byte, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate host key: %w", err)
+	}
+	block, err := gossh.MarshalPrivateKey(key, "go-game")
+	if err != nil {
+		return nil, fmt.Errorf("encode host key: %w", err)
+	}
+	payload := pem.EncodeToMemory(block)
+	if err := os.WriteFile(path, payload, 0o600); err != nil {
+		return nil, fmt.Errorf("write host key: %w", err)
+	}
+	return payload, nil
+}
+
+func loadAuthorizedKeys(path string) ([]ssh.PublicKey, error) {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read authorized keys: %w", err)
+	}
+	var keys []ssh.PublicKey
+	for len(payload) > 0 {
+		key, _, _, rest, err := gossh.ParseAuthorizedKey(payload)
+		if err != nil {
+			break
+		}
+		keys = append(keys, key)
+		payload = rest
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no keys in %s", path)
+	}
+	return keys, nil
+}
+
+func authorizedKey(authorized []ssh.PublicKey, key ssh.PublicKey) bool {
+	for _, candidate := range authorized {
+		if ssh.KeysEqual(candidate, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func DefaultAuthorizedKeys() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".ssh", "authorized_keys")
+}
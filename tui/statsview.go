@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+const (
+	statsRecentPurchases = 10
+	statsFastestCount    = 10
+)
+
+func (ui *UI) openStats() {
+	ui.statsScroll = 0
+	ui.mode = modeStats
+}
+
+func (ui *UI) handleStatsKey(event *tcell.EventKey, rows int) {
+	switch event.Key() {
+	case tcell.KeyUp:
+		ui.statsScroll--
+	case tcell.KeyDown:
+		ui.statsScroll++
+	case tcell.KeyPgUp:
+		ui.statsScroll -= rows
+	case tcell.KeyPgDn:
+		ui.statsScroll += rows
+	default:
+		ui.mode = modeMain
+	}
+	ui.statsScroll = engine.MaxInt(ui.statsScroll, 0)
+}
+
+func (ui *UI) drawStats(width, height int) {
+	ui.drawScrollLines(width, height, tr("Statistics"), tr("↑/↓ PgUp/PgDn scroll | any other key returns"), ui.statsLines())
+}
+
+func (ui *UI) drawScrollLines(width, height int, title, hint string, lines []detailLine) {
+	ui.drawText(2, 1, title, tcell.StyleDefault.Bold(true))
+	ui.drawText(2, height-2, truncate(hint, width-4), ui.palette().good)
+	rows := height - 5
+	ui.statsScroll = clamp(ui.statsScroll, 0, engine.MaxInt(len(lines)-rows, 0))
+	for index := ui.statsScroll; index < len(lines) && index-ui.statsScroll < rows; index++ {
+		ui.drawText(4, 3+index-ui.statsScroll, truncate(lines[index].text, width-8), lines[index].style)
+	}
+}
+
+func (ui *UI) statsLines() []detailLine {
+	stats := ui.game.Stats
+	plain := ui.palette().base
+	heading := plain.Bold(true)
+	lines := []detailLine{
+		{text: tr("Lifetime:"), style: heading},
+		{text: "  " + tr("started %s, played %s", stats.StartedAt.Format("2006-01-02 15:04"), stats.Playtime.Truncate(time.Second)), style: plain},
+		{text: "  " + tr("%d purchases, %d of %d achievements", len(stats.Purchases), len(stats.Achievements), len(engine.Achievements)), style: plain},
+		{text: "  " + tr("seed %d", ui.game.Seed), style: plain},
+		{text: tr("Resources:"), style: heading},
+	}
+	totals := engine.CopyResources(stats.Earned)
+	for resource, spent := range stats.Spent {
+		totals[resource] += spent
+	}
+	for _, resource := range engine.SortedKeys(totals) {
+		lines = append(lines, detailLine{text: "  " + tr("%s earned %s, spent %s", resource, ui.formatNumber(stats.Earned[resource]), ui.formatNumber(stats.Spent[resource])), style: plain})
+	}
+	lines = append(lines, detailLine{text: tr("Cycles per worker:"), style: heading})
+	for _, industry := range ui.game.Industries {
+		for _, worker := range industry.Workers {
+			cycles := stats.Cycles[engine.WorkerStatsKey(industry.Key, worker.Definition.Key)]
+			lines = append(lines, detailLine{text: fmt.Sprintf("  %s / %s: %s", industry.Name, worker.Definition.WorkerName, ui.formatNumber(cycles)), style: plain})
+		}
+	}
+	lines = append(lines, ui.purchaseLines(stats.Purchases, heading, plain)...)
+	return append(lines, ui.fastestLines(stats, heading, plain)...)
+}
+
+func (ui *UI) purchaseLines(purchases []engine.PurchaseRecord, heading, plain tcell.Style) []detailLine {
+	lines := []detailLine{{text: tr("Recent purchases:"), style: heading}}
+	if len(purchases) == 0 {
+		return append(lines, detailLine{text: "  " + tr("none yet"), style: plain})
+	}
+	for index := len(purchases) - 1; index >= 0 && index >= len(purchases)-statsRecentPurchases; index-- {
+		purchase := purchases[index]
+		text := "  " + tr("%s %s %d %s for %s", purchase.At.Format("15:04:05"), purchase.Kind, purchase.Count, ui.workerName(purchase.Industry, purchase.Worker), engine.FormatCost(purchase.Cost))
+		lines = append(lines, detailLine{text: text, style: plain})
+	}
+	return lines
+}
+
+func (ui *UI) workerName(industryKey, workerKey string) string {
+	for _, industry := range ui.game.Industries {
+		if industry.Key != industryKey {
+			continue
+		}
+		if index, ok := engine.FindWorkerIndex(industry.Workers, workerKey); ok {
+			return industry.Workers[index].Definition.WorkerName
+		}
+	}
+	return workerKey
+}
+
+func (ui *UI) fastestLines(stats engine.Statistics, heading, plain tcell.Style) []detailLine {
+	lines := []detailLine{{text: tr("Fastest milestones:"), style: heading}}
+	milestones := append([]engine.MilestoneRecord(nil), stats.Milestones...)
+	sort.SliceStable(milestones, func(a, b int) bool {
+		return milestones[a].At.Before(milestones[b].At)
+	})
+	if len(milestones) == 0 {
+		return append(lines, detailLine{text: "  " + tr("none yet"), style: plain})
+	}
+	for _, milestone := range milestones[:engine.MinInt(len(milestones), statsFastestCount)] {
+		text := "  " + tr("%s %s after %s", milestone.Resource, ui.formatNumber(milestone.Amount), milestone.At.Sub(stats.StartedAt).Truncate(time.Second))
+		lines = append(lines, detailLine{text: text, style: plain})
+	}
+	return lines
+}
@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+func (ui *UI) statusStyle() tcell.Style {
+	return ui.classStyle(ui.statusClass)
+}
+
+func (ui *UI) classStyle(class engine.StatusClass) tcell.Style {
+	switch class {
+	case engine.StatusError:
+		return ui.palette().bad.Bold(true)
+	case engine.StatusSuccess:
+		return ui.palette().good
+	}
+	return ui.palette().accent
+}
+
+func (ui *UI) statusTimeout() time.Duration {
+	if ui.settings.StatusTimeout > 0 {
+		return ui.settings.StatusTimeout
+	}
+	return engine.DefaultStatusTimeout
+}
+
+func (ui *UI) statusExpired(now time.Time) bool {
+	if ui.statusSticky {
+		return false
+	}
+	return now.Sub(ui.lastStatusAt) > ui.statusTimeout()
+}
+
+func (ui *UI) dismissStatus() {
+	ui.statusSticky = false
+	ui.lastStatusAt = time.Time{}
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+const (
+	maxStatusHistory = 50
+)
+
+func (ui *UI) recordStatus(at time.Time, message string) {
+	if message == "" {
+		return
+	}
+	ui.statusHistory = append(ui.statusHistory, logEntry{At: at, Kind: engine.NoticeStatus, Message: message})
+	if len(ui.statusHistory) > maxStatusHistory {
+		ui.statusHistory = ui.statusHistory[len(ui.statusHistory)-maxStatusHistory:]
+	}
+}
+
+func (ui *UI) openStatusHistory() {
+	if len(ui.statusHistory) == 0 {
+		ui.setStatus(engine.InfoStatus(tr("no status messages yet")))
+		return
+	}
+	ui.historyScroll = 0
+	ui.mode = modeHistory
+}
+
+func (ui *UI) handleHistoryKey(event *tcell.EventKey) {
+	switch event.Key() {
+	case tcell.KeyUp:
+		ui.historyScroll = engine.MaxInt(ui.historyScroll-1, 0)
+	case tcell.KeyDown:
+		ui.historyScroll = engine.MinInt(ui.historyScroll+1, len(ui.statusHistory)-1)
+	default:
+		ui.mode = modeMain
+	}
+}
+
+func (ui *UI) statusHistoryLines(rows int) []string {
+	lines := make([]string, 0, rows)
+	for index := len(ui.statusHistory) - 1 - ui.historyScroll; index >= 0 && len(lines) < rows; index-- {
+		entry := ui.statusHistory[index]
+		lines = append(lines, fmt.Sprintf("%s %s", entry.At.Format("15:04:05"), entry.Message))
+	}
+	return lines
+}
+
+func (ui *UI) drawStatusHistory(width, height int) {
+	title := fmt.Sprintf("Recent messages (%d)", len(ui.statusHistory))
+	ui.drawDialog(width, height, title, ui.statusHistoryLines(engine.MaxInt(height-7, 1)), "↑/↓ scroll | any other key closes")
+}
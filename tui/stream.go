@@ -0,0 +1,230 @@
+package tui
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+const (
+	streamInterval   = 500 * time.Millisecond
+	websocketGUID    = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	websocketVersion = "13"
+	websocketText    = 0x1
+	websocketClose   = 0x8
+	websocketFinBit  = 0x80
+)
+
+type streamMessage struct {
+	Type       string                    `json:"type"`
+	Resources  map[string]int            `json:"resources,omitempty"`
+	Removed    []string                  `json:"removed,omitempty"`
+	Rates      map[string]float64        `json:"rates,omitempty"`
+	NetWorth   *int                      `json:"netWorth,omitempty"`
+	Paused     *bool                     `json:"paused,omitempty"`
+	Revision   *int                      `json:"revision,omitempty"`
+	Workers    []streamWorker            `json:"workers,omitempty"`
+	Industries []engine.IndustrySnapshot `json:"industries,omitempty"`
+}
+
+type streamWorker struct {
+	Industry string `json:"industry"`
+	engine.WorkerSnapshot
+}
+
+func (ui *UI) streamHandler(token string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(r, token) {
+			writeJSON(w, http.StatusUnauthorized, apiResult{Message: "invalid token"})
+			return
+		}
+		conn, reader, ok := upgradeWebSocket(w, r)
+		if !ok {
+			return
+		}
+		defer conn.Close()
+		closed := make(chan struct{})
+		go discardFrames(reader, closed)
+		ticker := time.NewTicker(streamInterval)
+		defer ticker.Stop()
+		var last *engine.Snapshot
+		for {
+			result, ok := ui.callAPI(r.Context(), (*UI).apiState)
+			if !ok {
+				return
+			}
+			current := result.body.(engine.Snapshot)
+			if message, changed := diffState(last, current); changed {
+				if err := writeFrame(conn, websocketText, message); err != nil {
+					return
+				}
+			}
+			last = &current
+			select {
+			case <-ticker.C:
+			case <-closed:
+				return
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}
+}
+
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (net.Conn, *bufio.Reader, bool) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") || key == "" || r.Header.Get("Sec-WebSocket-Version") != websocketVersion {
+		w.Header().Set("Sec-WebSocket-Version", websocketVersion)
+		writeJSON(w, http.StatusUpgradeRequired, apiResult{Message: "websocket upgrade (version " + websocketVersion + ") required"})
+		return nil, nil, false
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		writeJSON(w, http.StatusInternalServerError, apiResult{Message: "websocket not supported"})
+		return nil, nil, false
+	}
+	conn, buffer, err := hijacker.Hijack()
+	if err != nil {
+		return nil, nil, false
+	}
+	digest := sha1.Sum([]byte(key + websocketGUID))
+	fmt.Fprintf(buffer, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n", base64.StdEncoding.EncodeToString(digest[:]))
+	if err := buffer.Flush(); err != nil {
+		conn.Close()
+		return nil, nil, false
+	}
+	return conn, buffer.Reader, true
+}
+
+func writeFrame(w io.Writer, opcode byte, message any) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("serialize stream message: %w", err)
+	}
+	header := []byte{websocketFinBit | opcode}
+	switch {
+	case len(payload) < 126:
+		header = append(header, byte(len(payload)))
+	case len(payload) <= 0xffff:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(len(payload)))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(len(payload)))
+	}
+	if _, err := w.Write(append(header, payload...)); err != nil {
+		return fmt.Errorf("write stream frame: %w", err)
+	}
+	return nil
+}
+
+func discardFrames(reader *bufio.Reader, closed chan<- struct{}) {
+	defer close(closed)
+	for {
+		header := make([]byte, 2)
+		if _, err := io.ReadFull(reader, header); err != nil {
+			return
+		}
+		length := uint64(header[1] & 0x7f)
+		switch length {
+		case 126:
+			extended := make([]byte, 2)
+			if _, err := io.ReadFull(reader, extended); err != nil {
+				return
+			}
+			length = uint64(binary.BigEndian.Uint16(extended))
+		case 127:
+			extended := make([]byte, 8)
+			if _, err := io.ReadFull(reader, extended); err != nil {
+				return
+			}
+			length = binary.BigEndian.Uint64(extended)
+		}
+		if header[1]&0x80 != 0 {
+			length += 4
+		}
+		if _, err := io.CopyN(io.Discard, reader, int64(length)); err != nil {
+			return
+		}
+		if header[0]&0x0f == websocketClose {
+			return
+		}
+	}
+}
+
+func fullState(current engine.Snapshot) streamMessage {
+	return streamMessage{
+		Type:       "full",
+		Resources:  current.Resources,
+		Rates:      current.Rates,
+		NetWorth:   &current.NetWorth,
+		Paused:     &current.Paused,
+		Revision:   &current.Revision,
+		Industries: current.Industries,
+	}
+}
+
+func sameShape(last, current engine.Snapshot) bool {
+	if len(last.Industries) != len(current.Industries) {
+		return false
+	}
+	for index, industry := range current.Industries {
+		previous := last.Industries[index]
+		if previous.Key != industry.Key || len(previous.Workers) != len(industry.Workers) {
+			return false
+		}
+	}
+	return true
+}
+
+func diffState(last *engine.Snapshot, current engine.Snapshot) (streamMessage, bool) {
+	if last == nil || !sameShape(*last, current) {
+		return fullState(current), true
+	}
+	message := streamMessage{Type: "diff", Resources: make(map[string]int), Rates: make(map[string]float64)}
+	for resource, amount := range current.Resources {
+		if previous, ok := last.Resources[resource]; !ok || previous != amount {
+			message.Resources[resource] = amount
+		}
+	}
+	for resource := range last.Resources {
+		if _, ok := current.Resources[resource]; !ok {
+			message.Removed = append(message.Removed, resource)
+		}
+	}
+	for resource, rate := range current.Rates {
+		if last.Rates[resource] != rate {
+			message.Rates[resource] = rate
+		}
+	}
+	if current.NetWorth != last.NetWorth {
+		message.NetWorth = &current.NetWorth
+	}
+	if current.Paused != last.Paused {
+		message.Paused = &current.Paused
+	}
+	if current.Revision != last.Revision {
+		message.Revision = &current.Revision
+	}
+	for industryIndex, industry := range current.Industries {
+		for workerIndex, worker := range industry.Workers {
+			if !reflect.DeepEqual(worker, last.Industries[industryIndex].Workers[workerIndex]) {
+				message.Workers = append(message.Workers, streamWorker{Industry: industry.Key, WorkerSnapshot: worker})
+			}
+		}
+	}
+	changed := len(message.Resources) > 0 || len(message.Removed) > 0 || len(message.Rates) > 0 ||
+		message.NetWorth != nil || message.Paused != nil || message.Revision != nil || len(message.Workers) > 0
+	return message, changed
+}
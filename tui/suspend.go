@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"os"
+	"os/signal"
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+const maxCatchUpSteps = 100000
+
+func (ui *UI) jobControlSignals() (<-chan os.Signal, func()) {
+	if !ui.jobControl {
+		return nil, func() {}
+	}
+	signals := make(chan os.Signal, 1)
+	notifyJobControl(signals)
+	return signals, func() { signal.Stop(signals) }
+}
+
+func (ui *UI) suspend() {
+	if !ui.jobControl {
+		ui.setStatus(engine.ErrorStatus(tr("suspend is not available in this session")))
+		return
+	}
+	if err := signalSuspend(); err != nil {
+		ui.setStatus(engine.ErrorStatus(tr("suspend failed: %v", err)))
+	}
+}
+
+func (ui *UI) handleJobControl(sig os.Signal) {
+	if suspendSignal(sig) {
+		ui.screen.Suspend()
+		if err := stopProcess(); err != nil {
+			ui.setStatus(engine.ErrorStatus(tr("suspend failed: %v", err)))
+		}
+	}
+	ui.screen.Resume()
+	ui.invalidateFrame()
+	ui.screen.Sync()
+	ui.catchUp(time.Now())
+}
+
+func (ui *UI) catchUp(now time.Time) {
+	if ui.coop != nil || ui.game.Replaying() {
+		return
+	}
+	gap := ui.clock.advance(now).Sub(ui.game.Now())
+	step := max(ui.tickInterval(), gap/maxCatchUpSteps)
+	for ; gap > 0; gap -= step {
+		ui.game.Step(min(step, gap))
+	}
+	ui.collectCompletions(now)
+}
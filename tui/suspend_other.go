@@ -0,0 +1,22 @@
+//go:build !unix
+
+package tui
+
+import (
+	"errors"
+	"os"
+)
+
+func notifyJobControl(signals chan<- os.Signal) {}
+
+func suspendSignal(sig os.Signal) bool {
+	return false
+}
+
+func signalSuspend() error {
+	return errors.New("job control is not supported on this platform")
+}
+
+func stopProcess() error {
+	return nil
+}
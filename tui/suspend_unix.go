@@ -0,0 +1,25 @@
+//go:build unix
+
+package tui
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func notifyJobControl(signals chan<- os.Signal) {
+	signal.Notify(signals, syscall.SIGTSTP, syscall.SIGCONT)
+}
+
+func suspendSignal(sig os.Signal) bool {
+	return sig == syscall.SIGTSTP
+}
+
+func signalSuspend() error {
+	return syscall.Kill(syscall.Getpid(), syscall.SIGTSTP)
+}
+
+func stopProcess() error {
+	return syscall.Kill(syscall.Getpid(), syscall.SIGSTOP)
+}
@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+)
+
+const (
+	tabMarkerAffordable = "$"
+	tabMarkerCompleted  = "!"
+)
+
+type tabKey struct {
+	label   string
+	markers string
+}
+
+func (ui *UI) industryTabs() []string {
+	ui.tabs = ui.tabs[:0]
+	for index, industry := range ui.game.Industries {
+		label := industry.Name
+		if label == "" {
+			label = industry.Key
+		}
+		key := tabKey{label: ui.withIcon(ui.game.Icons[industry.Resource], label), markers: ui.tabMarkers(index)}
+		ui.tabs = append(ui.tabs, ui.texts.tabs.get(key, key.text))
+	}
+	return ui.tabs
+}
+
+func (key tabKey) text() string {
+	if key.markers == "" {
+		return fmt.Sprintf("[%s]", key.label)
+	}
+	return fmt.Sprintf("[%s %s]", key.label, key.markers)
+}
+
+func industryHotkey(event *tcell.EventKey) (int, bool) {
+	if event.Key() >= tcell.KeyF1 && event.Key() < tcell.KeyF1+engine.MaxIndustries {
+		return int(event.Key() - tcell.KeyF1), true
+	}
+	if event.Key() == tcell.KeyRune && event.Modifiers()&tcell.ModAlt != 0 && event.Rune() >= '1' && event.Rune() < '1'+engine.MaxIndustries {
+		return int(event.Rune() - '1'), true
+	}
+	return 0, false
+}
+
+func (ui *UI) tabMarkers(index int) string {
+	if index == ui.activeIndustry {
+		return ""
+	}
+	affordable, pending := ui.industryAffordable(index), ui.tabPending[index]
+	switch {
+	case affordable && pending:
+		return tabMarkerAffordable + tabMarkerCompleted
+	case affordable:
+		return tabMarkerAffordable
+	case pending:
+		return tabMarkerCompleted
+	}
+	return ""
+}
+
+func (ui *UI) industryAffordable(index int) bool {
+	if ui.game.DevMode {
+		return false
+	}
+	for workerIndex, worker := range ui.game.Industries[index].Workers {
+		if engine.CanAfford(worker.Definition.Cost, ui.game.Resources) || ui.game.CanAffordUpgrade(index, workerIndex) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ui *UI) markPending(completion engine.Completion) {
+	if completion.Industry == ui.activeIndustry {
+		return
+	}
+	if ui.game.Industries[completion.Industry].Workers[completion.Worker].Auto {
+		return
+	}
+	if ui.tabPending == nil {
+		ui.tabPending = make(map[int]bool)
+	}
+	ui.tabPending[completion.Industry] = true
+}
+
+func (ui *UI) tabStyle(index int) tcell.Style {
+	if index == ui.activeIndustry {
+		return ui.palette().base.Reverse(true)
+	}
+	if ui.tabMarkers(index) != "" {
+		return ui.palette().highlight
+	}
+	return ui.palette().base
+}
@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"archuser.org/go-game/engine"
+)
+
+const textCacheLimit = 512
+
+type frameTexts struct {
+	worth       textCache[worthKey]
+	clock       textCache[clockKey]
+	tabs        textCache[tabKey]
+	titles      textCache[titleKey]
+	workers     textCache[workerKey]
+	rows        textCache[rowKey]
+	investments textCache[engine.Investment]
+	amounts     textCache[amountKey]
+	deltas      textCache[deltaKey]
+	rates       textCache[rateKey]
+}
+
+type textCache[K comparable] map[K]string
+
+func (c *textCache[K]) get(key K, build func() string) string {
+	if text, ok := (*c)[key]; ok {
+		return text
+	}
+	if *c == nil || len(*c) >= textCacheLimit {
+		*c = make(textCache[K])
+	}
+	text := build()
+	(*c)[key] = text
+	return text
+}
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"time"
+
+	"archuser.org/go-game/engine"
+)
+
+const (
+	toastDuration = 4 * time.Second
+	maxToasts     = 4
+	maxToastWidth = 40
+)
+
+type toast struct {
+	kind    string
+	message string
+	until   time.Time
+}
+
+func (ui *UI) pushToast(kind, message string, now time.Time) {
+	ui.toasts = append(ui.toasts, toast{kind: kind, message: message, until: now.Add(toastDuration)})
+}
+
+func (ui *UI) liveToasts(now time.Time) []toast {
+	live := ui.toasts[:0]
+	for _, current := range ui.toasts {
+		if now.Before(current.until) {
+			live = append(live, current)
+		}
+	}
+	ui.toasts = live
+	if len(live) > maxToasts {
+		return live[len(live)-maxToasts:]
+	}
+	return live
+}
+
+func (ui *UI) drawToasts(width int, now time.Time) {
+	for index, current := range ui.liveToasts(now) {
+		text := " " + truncate(current.message, maxToastWidth-2) + " "
+		x := width - textWidth(text) - 1
+		ui.drawText(x, 1+index, text, ui.logStyle(current.kind).Reverse(true))
+	}
+}
+
+func toastWorthy(kind string) bool {
+	return kind == engine.NoticeUnlock || kind == engine.NoticeMilestone || kind == engine.NoticeAchievement
+}
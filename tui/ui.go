@@ -0,0 +1,1112 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"archuser.org/go-game/engine"
+	"archuser.org/go-game/save"
+)
+
+const (
+	minWidth         = 85
+	minHeight        = 22
+	compactMinWidth  = 60
+	compactMinHeight = 16
+	hardcoreAutosave = 5 * time.Second
+	runReportFile    = "report.md"
+	defaultFPS       = 10
+	minFPS           = 1
+	maxFPS           = 60
+)
+
+type UI struct {
+	screen            Renderer
+	startedAt         time.Time
+	game              *engine.Engine
+	profile           save.Profile
+	activeIndustry    int
+	selectedWorker    int
+	statusMessage     string
+	lastStatusAt      time.Time
+	statusClass       engine.StatusClass
+	statusSticky      bool
+	workerScroll      int
+	lastSavedAt       time.Time
+	runEnded          bool
+	keys              *keymap
+	settings          Settings
+	mode              uiMode
+	remapIndex        int
+	remapWaiting      bool
+	statusHistory     []logEntry
+	historyScroll     int
+	searchQuery       string
+	consoleInput      string
+	workerFilter      workerFilter
+	workerSort        workerSort
+	countPrefix       int
+	pendingFirst      bool
+	dialog            confirmDialog
+	compact           bool
+	logEntries        []logEntry
+	logScroll         int
+	hideLog           bool
+	showDetails       bool
+	flashes           map[engine.WorkerRef]flash
+	toasts            []toast
+	cuedAt            map[string]time.Time
+	pendingCues       []pendingCue
+	apiCalls          chan apiCall
+	simMu             sync.Mutex
+	jobControl        bool
+	metrics           loopMetrics
+	perfLoggedAt      time.Time
+	coop              *coopLink
+	race              *raceLink
+	rates             map[string]float64
+	ratesStale        bool
+	dirty             bool
+	drawnAt           time.Time
+	chartResource     int
+	chartZoom         int
+	clock             simClock
+	quantity          quantityPrompt
+	achievementScroll int
+	leaderboard       leaderboardView
+	statsScroll       int
+	frame             frameBuffer
+	glyphBuffer       []glyph
+	footer            [2][]footerItem
+	labels            textCache[labelKey]
+	displayable       map[string]bool
+	tabs              []string
+	visible           []int
+	resourceOrder     []string
+	sparkline         []rune
+	texts             frameTexts
+	clearStyle        tcell.Style
+	regions           []hitRegion
+	mouseDown         bool
+	AutosaveEvery     time.Duration
+	FPS               int
+	TickRate          time.Duration
+	Bot               engine.Strategy
+	botAt             time.Time
+	Monochrome        bool
+	EventLog          io.Writer
+	Overlay           string
+	KeepAlive         bool
+	Seed              uint64
+	SeedSet           bool
+	overlayAt         time.Time
+	tabPending        map[int]bool
+	countOrigin       int
+	optionIndex       int
+	autosaveSaved     time.Duration
+	optionsParent     uiMode
+	menu              menuPage
+	menuPaused        bool
+	syncedAt          time.Time
+	syncedRevision    int
+	ConfigPath        string
+}
+
+func NewUI(game *engine.Engine, profile save.Profile, settings Settings) (*UI, error) {
+	screen, err := newTerminalRenderer()
+	if err != nil {
+		return nil, err
+	}
+	ui, err := NewUIWith(screen, game, profile, settings)
+	if err != nil {
+		screen.Fini()
+		return nil, err
+	}
+	ui.jobControl = true
+	return ui, nil
+}
+
+func NewUIWith(screen Renderer, game *engine.Engine, profile save.Profile, settings Settings) (*UI, error) {
+	keys := defaultKeymap()
+	if err := keys.apply(settings.Keys); err != nil {
+		return nil, fmt.Errorf("apply key bindings: %w", err)
+	}
+	clearStyle := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack)
+	screen.SetStyle(clearStyle)
+	screen.EnableMouse()
+	now := time.Now()
+	ui := &UI{screen: screen, game: game, profile: profile, keys: keys, settings: settings, startedAt: now, lastSavedAt: now, clock: newSimClock(game.Now()), clearStyle: clearStyle}
+	if profile.Hardcore() {
+		game.Hardcore = true
+		ui.AutosaveEvery = hardcoreAutosave
+	}
+	ui.observeGame()
+	return ui, nil
+}
+
+func (ui *UI) Close() {
+	if ui.screen != nil {
+		ui.screen.Fini()
+	}
+}
+
+func (ui *UI) Run() error {
+	defer ui.Close()
+
+	refresh := time.NewTicker(ui.frameInterval())
+	defer refresh.Stop()
+
+	eventCh := make(chan tcell.Event, maxEventBatch)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case eventCh <- ui.screen.PollEvent():
+			}
+		}
+	}()
+	defer close(done)
+	panics := make(chan any, 1)
+	go ui.simulate(done, panics)
+	signals, stopSignals := ui.jobControlSignals()
+	defer stopSignals()
+
+	redraw := true
+	for {
+		if redraw {
+			ui.render()
+			redraw = false
+		}
+		select {
+		case recovered := <-panics:
+			panic(recovered)
+		case sig := <-signals:
+			redraw = true
+			ui.withSim(func() { ui.handleJobControl(sig) })
+		case now := <-refresh.C:
+			ui.withSim(func() { redraw = ui.needsFrame(now) })
+		case call := <-ui.apiCalls:
+			ui.withSim(func() {
+				ui.serveAPI(call)
+				ui.dirty = true
+			})
+		case message, ok := <-ui.coopIncoming():
+			redraw = true
+			ui.withSim(func() { ui.handleCoop(message, ok) })
+		case ev := <-eventCh:
+			redraw = true
+			quit := false
+			batch := drainEvents(ev, eventCh)
+			ui.withSim(func() { quit = ui.handleEvents(batch) })
+			if quit {
+				return nil
+			}
+		}
+	}
+}
+
+func (ui *UI) frameInterval() time.Duration {
+	fps := ui.FPS
+	if fps <= 0 {
+		fps = ui.settings.FPS
+	}
+	if fps <= 0 {
+		fps = defaultFPS
+	}
+	return time.Second / time.Duration(clamp(fps, minFPS, maxFPS))
+}
+
+func (ui *UI) afterTick(now time.Time) {
+	ui.writeOverlay(now)
+	ui.checkRaceFinish()
+	if ui.runEnded || ui.game.Replaying() || ui.coop != nil {
+		return
+	}
+	if ui.profile.Hardcore() && ui.game.Bankrupt() {
+		ui.endRun("bankrupt", now)
+		return
+	}
+	if ui.AutosaveEvery > 0 && !ui.game.DevMode && now.Sub(ui.lastSavedAt) >= ui.AutosaveEvery {
+		ui.autosave(now)
+	}
+	ui.playBot(now)
+}
+
+func (ui *UI) autosave(now time.Time) {
+	ui.lastSavedAt = now
+	if err := save.Autosave(ui.game, ui.profile.SavePath()); err != nil {
+		ui.setStatus(engine.ErrorStatus(tr("autosave failed: %v", err)))
+		return
+	}
+	ui.markSynced(now)
+}
+
+func (ui *UI) endRun(reason string, now time.Time) {
+	ui.runEnded = true
+	ui.autosave(now)
+	if err := engine.WriteReport(filepath.Join(ui.profile.Dir(), runReportFile), ui.game, ui.startedAt, now); err != nil {
+		ui.setStatus(engine.ErrorStatus(tr("report failed: %v", err)))
+	}
+	if err := ui.profile.End(reason, now); err != nil {
+		ui.setStatus(engine.ErrorStatus(tr("end run failed: %v", err)))
+		return
+	}
+	ui.setStatus(engine.InfoStatus(tr("run ended: %s", reason)))
+}
+
+func (ui *UI) handleKey(event *tcell.EventKey) bool {
+	if ui.runEnded || event.Key() == tcell.KeyCtrlC {
+		return true
+	}
+	switch ui.mode {
+	case modeHelp:
+		ui.mode = modeMain
+		return false
+	case modeKeymap:
+		ui.handleKeymapKey(event)
+		return false
+	case modeConfirm:
+		ui.handleConfirmKey(event)
+		return false
+	case modeSearch:
+		ui.handleSearchKey(event)
+		return false
+	case modeConsole:
+		ui.handleConsoleKey(event)
+		return false
+	case modeHistory:
+		ui.handleHistoryKey(event)
+		return false
+	case modeChart:
+		ui.handleChartKey(event)
+		return false
+	case modeQuantity:
+		ui.handleQuantityKey(event)
+		return false
+	case modeAchievements:
+		ui.handleAchievementsKey(event)
+		return false
+	case modeStats:
+		_, height := ui.screen.Size()
+		ui.handleStatsKey(event, height-5)
+		return false
+	case modeIndustryStats:
+		_, height := ui.screen.Size()
+		ui.handleIndustryStatsKey(event, height-5)
+		return false
+	case modePlugins:
+		_, height := ui.screen.Size()
+		ui.handleStatsKey(event, height-5)
+		return false
+	case modeLeaderboard:
+		_, height := ui.screen.Size()
+		ui.handleLeaderboardKey(event, height-5)
+		return false
+	case modeOptions:
+		ui.handleOptionsKey(event)
+		return false
+	case modeMenu:
+		return ui.handleMenuKey(event)
+	}
+	if index, ok := industryHotkey(event); ok {
+		ui.selectIndustry(index)
+		return false
+	}
+	switch event.Key() {
+	case tcell.KeyEscape:
+		ui.openPauseMenu()
+	case tcell.KeyLeft:
+		ui.shiftIndustry(-1)
+	case tcell.KeyRight:
+		ui.shiftIndustry(1)
+	case tcell.KeyUp:
+		ui.shiftWorker(-1)
+	case tcell.KeyDown:
+		ui.shiftWorker(1)
+	case tcell.KeyPgUp:
+		ui.scrollLog(1)
+	case tcell.KeyPgDn:
+		ui.scrollLog(-1)
+	case tcell.KeyEnter:
+		ui.dismissStatus()
+	default:
+		ui.handleRune(keyRune(event))
+	}
+
+	return false
+}
+
+func (ui *UI) handleRune(key rune) {
+	if ui.takeCountDigit(key) {
+		return
+	}
+	act, ok := ui.keys.action(key)
+	pendingFirst := ui.pendingFirst
+	ui.pendingFirst = false
+	count := ui.takeCount()
+	if !ok {
+		return
+	}
+	switch act {
+	case actionIndustryPrev, actionIndustryNext, actionWorkerPrev, actionWorkerNext:
+		if count > 0 {
+			ui.selectedWorker = ui.countOrigin
+		}
+		ui.repeat(act, engine.MaxInt(count, 1))
+	case actionFirstWorker:
+		if !pendingFirst {
+			ui.pendingFirst = true
+			ui.countPrefix = count
+			return
+		}
+		ui.jumpWorker(count, 0)
+	case actionLastWorker:
+		ui.jumpWorker(count, -1)
+	default:
+		ui.perform(act)
+	}
+}
+
+func (ui *UI) blocked(act action) (engine.Status, bool) {
+	if ui.game.Replaying() && !playbackActions[act] {
+		return engine.ErrorStatus(tr("replay playback is read-only")), true
+	}
+	if ui.coop != nil && coopServerActions[act] {
+		return engine.ErrorStatus(tr("%s is handled by the co-op server", act)), true
+	}
+	return engine.Status{}, false
+}
+
+func (ui *UI) perform(act action) {
+	if status, blocked := ui.blocked(act); blocked {
+		ui.setStatus(status)
+		return
+	}
+	switch act {
+	case actionIndustryPrev:
+		ui.shiftIndustry(-1)
+	case actionIndustryNext:
+		ui.shiftIndustry(1)
+	case actionWorkerPrev:
+		ui.shiftWorker(-1)
+	case actionWorkerNext:
+		ui.shiftWorker(1)
+	case actionBuy, actionBuyQuantity, actionBuyMilestone, actionRun, actionUpgrade:
+		if !ui.hasSelection() {
+			ui.setStatus(engine.ErrorStatus(tr("no worker selected")))
+			return
+		}
+		ui.performOnSelection(act)
+	case actionBuyMode:
+		ui.game.BuyModeMax = !ui.game.BuyModeMax
+		ui.setStatus(engine.InfoStatus(ui.buyModeLabel()))
+	case actionRunLowest:
+		ui.setStatus(ui.runLowestAvailable(ui.game.Now()))
+	case actionSave:
+		ui.setStatus(ui.guardDevMode("save", ui.saveGame))
+	case actionLoad:
+		ui.setStatus(ui.guardDevMode("load", ui.guardHardcore("load", ui.loadGame)))
+	case actionExport:
+		ui.setStatus(ui.exportStats())
+	case actionHelp:
+		ui.mode = modeHelp
+	case actionKeymap:
+		ui.openKeymap()
+	case actionNotation:
+		ui.toggleNotation()
+	case actionLog:
+		ui.toggleLog()
+	case actionDetails:
+		ui.showDetails = !ui.showDetails
+	case actionFirstWorker:
+		ui.jumpWorker(0, 0)
+	case actionLastWorker:
+		ui.jumpWorker(0, -1)
+	case actionSearch:
+		ui.openSearch()
+	case actionFilter:
+		ui.cycleFilter()
+	case actionSort:
+		ui.cycleSort()
+	case actionHistory:
+		ui.openStatusHistory()
+	case actionPalette:
+		ui.cyclePalette()
+	case actionLayout:
+		ui.cycleLayout()
+	case actionOptions:
+		ui.openOptions(modeMain)
+	case actionChart:
+		ui.openChart()
+	case actionAchievements:
+		ui.openAchievements()
+	case actionStats:
+		ui.openStats()
+	case actionIndustry:
+		ui.openIndustryStats()
+	case actionConsole:
+		ui.openConsole()
+	case actionSuspend:
+		ui.suspend()
+	case actionUndo:
+		ui.setStatus(ui.game.Undo())
+	case actionRedo:
+		ui.setStatus(ui.game.Redo())
+	case actionPlugins:
+		ui.openPlugins()
+	case actionLeaderboard:
+		ui.openLeaderboard()
+	case actionPause:
+		ui.togglePause()
+	case actionSlower:
+		ui.shiftSpeed(-1)
+	case actionFaster:
+		ui.shiftSpeed(1)
+	}
+}
+
+func (ui *UI) performOnSelection(act action) {
+	switch act {
+	case actionBuy:
+		ui.buySelected()
+	case actionBuyQuantity:
+		ui.openQuantity()
+	case actionBuyMilestone:
+		ui.buyMilestone()
+	case actionRun:
+		ui.setStatus(ui.game.StartRun(ui.activeIndustry, ui.selectedWorker, ui.game.Now()))
+	case actionUpgrade:
+		ui.upgradeSelected()
+	}
+}
+
+func (ui *UI) selectIndustry(index int) {
+	if index < 0 || index >= len(ui.game.Industries) {
+		return
+	}
+	ui.activeIndustry = index
+	ui.selectedWorker = 0
+	ui.workerScroll = 0
+	delete(ui.tabPending, index)
+}
+
+func (ui *UI) shiftIndustry(delta int) {
+	count := len(ui.game.Industries)
+	if count == 0 {
+		return
+	}
+	ui.selectIndustry(((ui.activeIndustry+delta)%count + count) % count)
+}
+
+func (ui *UI) shiftWorker(delta int) {
+	visible := ui.visibleWorkers()
+	if len(visible) == 0 {
+		return
+	}
+	position, _ := ui.selectedPosition(visible)
+	ui.selectedWorker = visible[clamp(position+delta, 0, len(visible)-1)]
+}
+
+func (ui *UI) runLowestAvailable(now time.Time) engine.Status {
+	industry := ui.game.Industries[ui.activeIndustry]
+	for index, worker := range industry.Workers {
+		if worker.Auto || worker.Running {
+			continue
+		}
+		if worker.Owned == 0 {
+			continue
+		}
+		return ui.game.StartRun(ui.activeIndustry, index, now)
+	}
+	return engine.ErrorStatus(tr("no manual workers available"))
+}
+
+func (ui *UI) draw() {
+	width, height := ui.screen.Size()
+	ui.beginFrame(width, height)
+	ui.regions = ui.regions[:0]
+	ui.drawFrame(width, height)
+	ui.flushFrame()
+}
+
+func (ui *UI) drawFrame(width, height int) {
+	if width < compactMinWidth || height < compactMinHeight {
+		ui.drawTooSmall(width, height)
+		return
+	}
+	ui.compact = width < minWidth || height < minHeight
+	if ui.runEnded {
+		ui.drawRunEnded(width, height)
+		return
+	}
+	switch ui.mode {
+	case modeHelp:
+		ui.drawHelp(width, height)
+		return
+	case modeKeymap:
+		ui.drawKeymap(width, height)
+		return
+	case modeChart:
+		ui.drawChart(width, height)
+		return
+	case modeAchievements:
+		ui.drawAchievements(width, height)
+		return
+	case modeStats:
+		ui.drawStats(width, height)
+		return
+	case modeIndustryStats:
+		ui.drawIndustryStats(width, height)
+		return
+	case modePlugins:
+		ui.drawPlugins(width, height)
+		return
+	case modeLeaderboard:
+		ui.drawLeaderboard(width, height)
+		return
+	case modeMenu:
+		ui.drawMenu(width, height)
+		return
+	}
+	if ui.mode == modeOptions && ui.optionsParent == modeMenu {
+		ui.drawMenu(width, height)
+		ui.drawOptions(width, height)
+		return
+	}
+
+	if ui.compact {
+		ui.drawCompact(width, height)
+	} else {
+		ui.drawMain(width, height)
+	}
+	ui.drawToasts(width, time.Now())
+	switch ui.mode {
+	case modeConfirm:
+		ui.regions = ui.regions[:0]
+		ui.drawDialog(width, height, ui.dialog.title, ui.dialog.lines, ui.dialogHint())
+	case modeHistory:
+		ui.regions = ui.regions[:0]
+		ui.drawStatusHistory(width, height)
+	case modeQuantity:
+		ui.regions = ui.regions[:0]
+		ui.drawQuantity(width, height)
+	case modeOptions:
+		ui.regions = ui.regions[:0]
+		ui.drawOptions(width, height)
+	}
+}
+
+func (ui *UI) drawTooSmall(width, height int) {
+	message := tr("Terminal too small (%dx%d). Need at least %dx%d.", width, height, compactMinWidth, compactMinHeight)
+	ui.drawTextCentered(width, height/2, message, ui.palette().bad)
+	ui.drawTextCentered(width, height/2+2, tr("Resize the window to continue."), ui.palette().base)
+}
+
+func (ui *UI) drawRunEnded(width, height int) {
+	message := tr("Hardcore run ended: %s.", ui.profile.EndReason)
+	ui.drawTextCentered(width, height/2, message, ui.palette().bad.Bold(true))
+	ui.drawTextCentered(width, height/2+2, tr("Press any key to quit."), ui.palette().base)
+}
+
+func (ui *UI) drawHeader(width int) {
+	title := tr("Go Game - Industry Ladder")
+	ui.drawText(2, 1, title, tcell.StyleDefault.Bold(true))
+	x := 2 + textWidth(title) + 1
+	build := engine.CurrentBuild().Short()
+	ui.drawText(x, 1, build, ui.palette().locked)
+	x += textWidth(build) + 2
+	if badge := ui.clock.badge(); badge != "" {
+		ui.drawText(x, 1, badge, ui.palette().highlight.Reverse(true).Bold(true))
+		x += textWidth(badge) + 2
+	}
+	worth := worthKey{netWorth: ui.game.NetWorth(), scientific: ui.settings.Scientific, devMode: ui.game.DevMode, hardcore: ui.profile.Hardcore()}
+	label := ui.texts.worth.get(worth, worth.text)
+	startX := width - textWidth(label) - 2
+	clock := ui.headerClock(time.Now())
+	ui.drawText(x, 1, truncate(clock, engine.MaxInt(startX-x-2, 0)), ui.palette().locked)
+	if startX > x {
+		ui.drawText(startX, 1, label, tcell.StyleDefault.Bold(true))
+	}
+
+	ui.drawTabs(2, 2, width-4, ui.industryTabs())
+}
+
+type worthKey struct {
+	netWorth   int
+	scientific bool
+	devMode    bool
+	hardcore   bool
+}
+
+func (key worthKey) text() string {
+	label := tr("net worth %s", engine.FormatNumber(key.netWorth, key.scientific))
+	if key.devMode {
+		label += " | " + tr("developer mode")
+	} else if key.hardcore {
+		label += " | " + tr("hardcore")
+	}
+	return label
+}
+
+func (ui *UI) drawTabs(x, y, width int, tabs []string) {
+	if len(tabs) == 0 {
+		return
+	}
+	first, last := visibleTabs(tabs, ui.activeIndustry, width-4)
+	startX := x
+	if first > 0 {
+		ui.drawText(startX, y, "<", tcell.StyleDefault.Bold(true))
+		ui.addRegion(startX, y, 1, func() { ui.shiftIndustry(-1) })
+	}
+	startX += 2
+	for idx := first; idx <= last; idx++ {
+		tab := truncate(tabs[idx], width-4)
+		ui.drawText(startX, y, tab, ui.tabStyle(idx))
+		ui.addPickRegion(startX, y, textWidth(tab), pickIndustry, idx)
+		startX += textWidth(tab) + 1
+	}
+	if last < len(tabs)-1 {
+		ui.drawText(startX, y, ">", tcell.StyleDefault.Bold(true))
+		ui.addRegion(startX, y, 1, func() { ui.shiftIndustry(1) })
+	}
+}
+
+func visibleTabs(tabs []string, active, width int) (int, int) {
+	active = clamp(active, 0, len(tabs)-1)
+	first, last := active, active
+	used := textWidth(tabs[active])
+	for {
+		grew := false
+		if last+1 < len(tabs) && used+1+textWidth(tabs[last+1]) <= width {
+			last++
+			used += 1 + textWidth(tabs[last])
+			grew = true
+		}
+		if first > 0 && used+1+textWidth(tabs[first-1]) <= width {
+			first--
+			used += 1 + textWidth(tabs[first])
+			grew = true
+		}
+		if !grew {
+			return first, last
+		}
+	}
+}
+
+type rateKey struct {
+	rate       float64
+	scientific bool
+}
+
+func (ui *UI) formatRate(rate float64) string {
+	key := rateKey{rate: rate, scientific: ui.settings.Scientific}
+	return ui.texts.rates.get(key, key.text)
+}
+
+func (key rateKey) text() string {
+	rate := key.rate
+	sign := "+"
+	if rate < 0 {
+		sign = "-"
+		rate = -rate
+	}
+	if rate < 10 {
+		return fmt.Sprintf("%s%s/s", sign, engine.TrimDecimals(rate))
+	}
+	return fmt.Sprintf("%s%s/s", sign, engine.FormatNumber(int(math.Round(rate)), key.scientific))
+}
+
+func (ui *UI) rateStyle(rate float64) tcell.Style {
+	switch {
+	case rate > 0:
+		return ui.palette().good
+	case rate < 0:
+		return ui.palette().bad
+	}
+	return ui.palette().base
+}
+
+func (ui *UI) drawWorkers(x, y, width, height int) {
+	industry := ui.game.Industries[ui.activeIndustry]
+	ui.drawText(x, y, truncate(ui.workerListTitle(industry.Name), width-2), tcell.StyleDefault.Bold(true))
+	visible := ui.ensureSelectionVisible()
+	if len(visible) == 0 {
+		ui.drawText(x+2, y+1, tr("no workers match"), tcell.StyleDefault.Dim(true))
+		return
+	}
+	selected, _ := ui.selectedPosition(visible)
+	start := ui.workerScroll
+	end := engine.MinInt(len(visible), start+height-2)
+	if selected >= end {
+		start = selected - (height - 3)
+		ui.workerScroll = start
+		end = engine.MinInt(len(visible), start+height-2)
+	}
+	if selected < start {
+		start = selected
+		ui.workerScroll = start
+		end = engine.MinInt(len(visible), start+height-2)
+	}
+
+	now := time.Now()
+	best, hasBest := ui.bestInvestment()
+	for position := start; position < end; position++ {
+		i := visible[position]
+		row := rowKey{line: ui.workerLine(industry.Workers[i]), players: ui.coopCursorLabel(industry.Key, industry.Workers[i].Definition.Key)}
+		style := ui.workerStyle(industry.Workers[i])
+		if hasBest && best.Worker == i {
+			row.investment = ui.investmentLabel(best)
+			style = style.Underline(true)
+		}
+		if current, ok := ui.activeFlash(i, now); ok {
+			row.flash = current.label
+			style = ui.flashStyle()
+		}
+		line := ui.texts.rows.get(row, row.text)
+		lineWidth := width - 4
+		ui.addPickRegion(x+2, y+1+(position-start), lineWidth, pickWorker, i)
+		if i == ui.selectedWorker {
+			style = style.Reverse(true)
+			if !ui.compact {
+				lineWidth = ui.drawButtonsRight(x+width-1, y+1+(position-start), x+2+buttonRoomMin, workerButtons) - x - 3
+			}
+		}
+		ui.drawText(x+2, y+1+(position-start), truncate(line, lineWidth), style)
+	}
+}
+
+type workerKey struct {
+	industry   int
+	worker     string
+	owned      int
+	tier       int
+	running    bool
+	remaining  time.Duration
+	spinner    string
+	auto       bool
+	affordable bool
+	compact    bool
+	scientific bool
+	buyMax     bool
+	bulk       int
+}
+
+type rowKey struct {
+	line       string
+	players    string
+	investment string
+	flash      string
+}
+
+func (key rowKey) text() string {
+	line := key.line
+	for _, suffix := range []string{key.players, key.investment, key.flash} {
+		if suffix != "" {
+			line = fmt.Sprintf("%s  %s", line, suffix)
+		}
+	}
+	return line
+}
+
+func (ui *UI) workerLine(worker engine.WorkerState) string {
+	key := workerKey{
+		industry:   ui.activeIndustry,
+		worker:     worker.Definition.Key,
+		owned:      worker.Owned,
+		tier:       worker.Tier,
+		running:    worker.Running,
+		auto:       worker.Auto,
+		affordable: ui.workerAffordable(worker),
+		compact:    ui.compact,
+		scientific: ui.settings.Scientific,
+		buyMax:     ui.game.BuyModeMax,
+		bulk:       ui.bulkCount(worker),
+	}
+	if worker.Running {
+		key.remaining = max(worker.EndsAt.Sub(ui.game.Now()).Truncate(time.Second), 0)
+		key.spinner = ui.spinner(time.Now())
+	}
+	return ui.texts.workers.get(key, func() string { return ui.buildWorkerLine(worker, key) })
+}
+
+func (ui *UI) buildWorkerLine(worker engine.WorkerState, key workerKey) string {
+	status := tr("idle")
+	if worker.Owned == 0 {
+		status = tr("locked")
+	}
+	if worker.Running {
+		status = key.spinner + tr("running %s", key.remaining)
+		if ui.compact {
+			status = key.spinner + tr("run %s", key.remaining)
+		}
+	}
+	autoLabel := tr("manual")
+	if worker.Auto {
+		autoLabel = tr("auto")
+	}
+	marker := " "
+	if key.affordable {
+		marker = "*"
+	}
+	if ui.compact {
+		line := fmt.Sprintf("%s%s x%s T%d %s %s", marker, ui.workerLabel(worker), ui.formatNumber(worker.Owned), worker.Tier, status, string([]rune(autoLabel)[:1]))
+		if key.bulk > 0 {
+			line = fmt.Sprintf("%s +%s", line, ui.formatNumber(key.bulk))
+		}
+		return line
+	}
+	line := tr("%s %s | owned %s | tier %d | %s | %s", marker, ui.workerLabel(worker), ui.formatNumber(worker.Owned), worker.Tier, status, autoLabel)
+	if preview := ui.bulkPreview(worker); preview != "" {
+		line = fmt.Sprintf("%s | %s", line, preview)
+	}
+	return line
+}
+
+func (ui *UI) bulkCount(worker engine.WorkerState) int {
+	if !ui.game.BuyModeMax {
+		return 0
+	}
+	return engine.MaxAffordable(worker.Definition.Cost, ui.game.Resources)
+}
+
+func (ui *UI) bulkPreview(worker engine.WorkerState) string {
+	if !ui.game.BuyModeMax {
+		return ""
+	}
+	count := ui.bulkCount(worker)
+	if count <= 0 {
+		return tr("max: none affordable")
+	}
+	return tr("max: +%s -> %s for %s", ui.formatNumber(count), ui.formatNumber(worker.Owned+count), ui.costText(engine.MultiplyCost(worker.Definition.Cost, count)))
+}
+
+func (ui *UI) drawFooter(x, y, width int) {
+	if ui.footer[0] == nil {
+		ui.footer = ui.footerRows()
+	}
+	ui.drawFooterItems(x, y-1, width-2, ui.footer[0])
+	ui.drawFooterItems(x, y, width-2, ui.footer[1])
+	right := ui.drawButtonsRight(width-2, y-2, x+buttonRoomMin, workerButtons)
+	ui.drawStatus(x, y-2, ui.drawSaveIndicator(right-1, y-2, x+buttonRoomMin))
+}
+
+func (ui *UI) footerRows() [2][]footerItem {
+	controlsTop := []footerItem{
+		{label: tr("%s/%s or ←/→ switch industry", ui.keys.label(actionIndustryPrev), ui.keys.label(actionIndustryNext))},
+		{label: tr("%s/%s or ↑/↓ select worker", ui.keys.label(actionWorkerPrev), ui.keys.label(actionWorkerNext))},
+		ui.footerAction(actionBuy, "buy"),
+		ui.footerAction(actionExport, "export"),
+		ui.footerAction(actionHelp, "help"),
+	}
+	controlsBottom := []footerItem{
+		ui.footerAction(actionRun, "run"),
+		ui.footerAction(actionRunLowest, "global run"),
+		ui.footerAction(actionUpgrade, "upgrade"),
+		ui.footerAction(actionBuyMode, "toggle buy mode"),
+		ui.footerAction(actionSave, "save"),
+		ui.footerAction(actionLoad, "load"),
+		{label: tr("esc menu")},
+	}
+	return [2][]footerItem{controlsTop, controlsBottom}
+}
+
+func (ui *UI) drawStatus(x, y, width int) {
+	status, style := ui.statusMessage, ui.statusStyle()
+	if ui.statusSticky {
+		status = tr("%s (enter to dismiss)", status)
+	}
+	if ui.statusExpired(time.Now()) {
+		status, style = ui.buyModeLabel(), ui.palette().good
+	}
+	if ui.mode == modeConsole {
+		status, style = ui.consolePrompt(), ui.palette().accent
+	}
+	ui.drawText(x, y, truncate(status, width-x-2), style)
+}
+
+func (ui *UI) drawFooterItems(x, y, limit int, items []footerItem) {
+	for index, item := range items {
+		label := item.label
+		if index > 0 {
+			ui.drawText(x, y, " | ", tcell.StyleDefault)
+			x += 3
+		}
+		if x+textWidth(label) > limit {
+			ui.drawText(x, y, truncate(label, limit-x), tcell.StyleDefault)
+			return
+		}
+		ui.drawText(x, y, label, tcell.StyleDefault)
+		if item.action != "" {
+			ui.addActionRegion(x, y, textWidth(label), item.action)
+		}
+		x += textWidth(label)
+	}
+}
+
+func (ui *UI) formatNumber(value int) string {
+	return engine.FormatNumber(value, ui.settings.Scientific)
+}
+
+func (ui *UI) toggleNotation() {
+	ui.settings.Scientific = !ui.settings.Scientific
+	label := tr("numbers: abbreviated")
+	if ui.settings.Scientific {
+		label = tr("numbers: scientific")
+	}
+	ui.setStatus(ui.savedSetting(label))
+}
+
+func (ui *UI) setStatus(status engine.Status) {
+	message := status.Message
+	ui.statusMessage = message
+	ui.lastStatusAt = time.Now()
+	ui.statusClass = status.Class
+	ui.statusSticky = ui.settings.StickyErrors && ui.statusClass == engine.StatusError
+	ui.logStatus(message)
+	ui.appendLog(ui.lastStatusAt, engine.NoticeStatus, message)
+	ui.recordStatus(ui.lastStatusAt, message)
+}
+
+func (ui *UI) currentStatus() engine.Status {
+	return engine.Status{Message: ui.statusMessage, Class: ui.statusClass}
+}
+
+func (ui *UI) buyModeLabel() string {
+	if ui.game.BuyModeMax {
+		return tr("buy mode: 100%")
+	}
+	return tr("buy mode: 1x")
+}
+
+func (ui *UI) savedSetting(label string) engine.Status {
+	if err := ui.settings.Save(); err != nil {
+		return engine.ErrorStatus(tr("%s (save settings failed: %v)", label, err))
+	}
+	return engine.InfoStatus(label)
+}
+
+func (ui *UI) guardDevMode(action string, fn func() engine.Status) engine.Status {
+	if ui.game.DevMode {
+		return engine.ErrorStatus(tr("%s disabled in developer mode", action))
+	}
+	return fn()
+}
+
+func (ui *UI) guardHardcore(action string, fn func() engine.Status) func() engine.Status {
+	return func() engine.Status {
+		if ui.profile.Hardcore() {
+			return engine.ErrorStatus(tr("%s disabled in hardcore mode", action))
+		}
+		return fn()
+	}
+}
+
+func (ui *UI) saveGame() engine.Status {
+	return ui.saveTo(ui.profile.SavePath())
+}
+
+func (ui *UI) saveTo(path string) engine.Status {
+	if err := save.Write(ui.game, path); err != nil {
+		return engine.ErrorStatus(tr("save failed: %v", err))
+	}
+	ui.lastSavedAt = time.Now()
+	ui.markSynced(ui.lastSavedAt)
+	return engine.SuccessStatus(tr("saved to %s", path))
+}
+
+func (ui *UI) loadGame() engine.Status {
+	return ui.loadFrom(ui.profile.SavePath())
+}
+
+func (ui *UI) loadFrom(path string) engine.Status {
+	if status, locked := ui.raceLocked(tr("load")); locked {
+		return status
+	}
+	if err := save.Load(ui.game, path); err != nil {
+		return engine.ErrorStatus(tr("load failed: %v", err))
+	}
+	ui.resetView()
+	ui.markSynced(time.Now())
+	return engine.SuccessStatus(tr("loaded %s", path))
+}
+
+func (ui *UI) resetView() {
+	ui.activeIndustry = clamp(ui.activeIndustry, 0, len(ui.game.Industries)-1)
+	ui.selectedWorker = 0
+	ui.workerScroll = 0
+}
+
+func (ui *UI) exportStats() engine.Status {
+	now := time.Now()
+	path := filepath.Join(ui.profile.Dir(), fmt.Sprintf("stats-%s.json", now.Format("20060102-150405")))
+	if err := engine.WriteStatsFile(path, ui.game.ExportStats(now), "json"); err != nil {
+		return engine.ErrorStatus(tr("export failed: %v", err))
+	}
+	return engine.SuccessStatus(tr("exported %s", path))
+}
+
+func (ui *UI) drawText(x, y int, text string, style tcell.Style) {
+	if plainASCII(text) {
+		for index := 0; index < len(text); index++ {
+			ui.setCell(x+index, y, rune(text[index]), style)
+		}
+		return
+	}
+	ui.glyphBuffer = visualOrder(appendGlyphs(ui.glyphBuffer[:0], text))
+	column := 0
+	for _, char := range ui.glyphBuffer {
+		ui.setGlyph(x+column, y, char, style)
+		column += char.width
+	}
+}
+
+func textWidth(text string) int {
+	return stringWidth(text)
+}
+
+func (ui *UI) drawTextCentered(width, y int, text string, style tcell.Style) {
+	start := (width - textWidth(text)) / 2
+	ui.drawText(start, y, text, style)
+}
+
+func truncate(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if stringWidth(text) <= width {
+		return text
+	}
+	if plainASCII(text) {
+		return asciiEllipsis(text, width)
+	}
+	chars := glyphs(text)
+	if width <= 3 {
+		return glyphString(fitGlyphs(chars, width))
+	}
+	return glyphString(fitGlyphs(chars, width-3)) + "..."
+}
+
+func asciiEllipsis(text string, width int) string {
+	if width <= 3 {
+		return text[:width]
+	}
+	return text[:width-3] + "..."
+}
+
+func clamp(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
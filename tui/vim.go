@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"archuser.org/go-game/engine"
+)
+
+const maxCountPrefix = 9999
+
+func (ui *UI) takeCountDigit(key rune) bool {
+	if key < '0' || key > '9' {
+		return false
+	}
+	if _, bound := ui.keys.action(key); bound {
+		return false
+	}
+	if key == '0' && ui.countPrefix == 0 {
+		ui.jumpWorker(0, -1)
+		return true
+	}
+	if ui.countPrefix == 0 {
+		ui.countOrigin = ui.selectedWorker
+	}
+	ui.countPrefix = engine.MinInt(ui.countPrefix*10+int(key-'0'), maxCountPrefix)
+	ui.jumpWorker(ui.countPrefix, 0)
+	return true
+}
+
+func (ui *UI) takeCount() int {
+	count := ui.countPrefix
+	ui.countPrefix = 0
+	return count
+}
+
+func (ui *UI) repeat(act action, count int) {
+	switch act {
+	case actionIndustryPrev:
+		ui.shiftIndustry(-count)
+	case actionIndustryNext:
+		ui.shiftIndustry(count)
+	case actionWorkerPrev:
+		ui.shiftWorker(-count)
+	case actionWorkerNext:
+		ui.shiftWorker(count)
+	}
+}
+
+func (ui *UI) jumpWorker(line, fallback int) {
+	visible := ui.visibleWorkers()
+	if len(visible) == 0 {
+		return
+	}
+	target := fallback
+	if target < 0 {
+		target = len(visible) - 1
+	}
+	if line > 0 {
+		target = line - 1
+	}
+	ui.selectedWorker = visible[clamp(target, 0, len(visible)-1)]
+}
@@ -0,0 +1,108 @@
+"use strict";
+
+const token = new URLSearchParams(location.search).get("token") || "";
+const state = { resources: {}, rates: {}, industries: [] };
+
+function applyMessage(message) {
+  if (message.type === "full") {
+    state.industries = message.industries;
+    state.resources = {};
+    state.rates = {};
+  }
+  Object.assign(state.resources, message.resources || {});
+  Object.assign(state.rates, message.rates || {});
+  for (const resource of message.removed || []) {
+    delete state.resources[resource];
+  }
+  for (const key of ["netWorth", "paused", "revision"]) {
+    if (key in message) {
+      state[key] = message[key];
+    }
+  }
+  for (const update of message.workers || []) {
+    const industry = state.industries.find((entry) => entry.key === update.industry);
+    const index = industry.workers.findIndex((worker) => worker.key === update.key);
+    industry.workers[index] = update;
+  }
+  render();
+}
+
+function cost(amounts) {
+  return Object.keys(amounts).sort().map((resource) => `${resource} ${amounts[resource]}`).join(", ");
+}
+
+function cell(row, text, className) {
+  const td = row.insertCell();
+  td.textContent = text;
+  if (className) {
+    td.className = className;
+  }
+  return td;
+}
+
+function render() {
+  document.getElementById("networth").textContent = `net worth ${state.netWorth}${state.paused ? " | PAUSED" : ""}`;
+  const resources = document.getElementById("resources");
+  resources.replaceChildren();
+  for (const resource of Object.keys(state.resources).sort()) {
+    const row = resources.insertRow();
+    cell(row, resource);
+    cell(row, state.resources[resource], "number");
+    cell(row, `+${(state.rates[resource] || 0).toFixed(2)}/s`, "number");
+  }
+  const industries = document.getElementById("industries");
+  industries.replaceChildren();
+  for (const industry of state.industries) {
+    const heading = document.createElement("h2");
+    heading.textContent = industry.name;
+    const table = document.createElement("table");
+    for (const worker of industry.workers) {
+      const row = table.insertRow();
+      row.className = worker.running ? "running" : "";
+      cell(row, worker.name);
+      cell(row, `owned ${worker.owned}`);
+      cell(row, `tier ${worker.tier}`);
+      cell(row, worker.running ? "running" : worker.auto ? "auto" : "idle");
+      cell(row, `buy ${cost(worker.buyCost)}`);
+      const controls = cell(row, "");
+      for (const kind of ["buy", "run", "upgrade"]) {
+        const button = document.createElement("button");
+        button.textContent = kind;
+        button.onclick = () => act(kind, { industry: industry.key, worker: worker.key });
+        controls.append(button, " ");
+      }
+    }
+    industries.append(heading, table);
+  }
+}
+
+async function act(kind, body) {
+  const response = await fetch(`/api/${kind}`, {
+    method: "POST",
+    headers: { Authorization: `Bearer ${token}`, "Content-Type": "application/json" },
+    body: body ? JSON.stringify(body) : undefined,
+  });
+  const result = await response.json();
+  const message = document.getElementById("message");
+  message.textContent = result.message;
+  message.className = result.ok ? "" : "error";
+}
+
+function connect() {
+  const status = document.getElementById("status");
+  const scheme = location.protocol === "https:" ? "wss" : "ws";
+  const socket = new WebSocket(`${scheme}://${location.host}/api/stream?token=${encodeURIComponent(token)}`);
+  socket.onopen = () => {
+    status.textContent = "live";
+    status.className = "";
+  };
+  socket.onmessage = (event) => applyMessage(JSON.parse(event.data));
+  socket.onclose = () => {
+    status.textContent = "disconnected, retrying…";
+    status.className = "offline";
+    setTimeout(connect, 2000);
+  };
+}
+
+document.querySelector("[data-save]").onclick = () => act("save");
+connect();